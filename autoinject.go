@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// AutoInject marks a struct for injection on bind, see AutoInjectOnBind. It is meant to be embedded:
+//
+//	type MyService struct {
+//		inject.AutoInject
+//		Log *logrus.Entry `inject:""`
+//	}
+type AutoInject struct{}
+
+func (AutoInject) autoInject() {}
+
+type autoInjectable interface {
+	autoInject()
+}
+
+// AutoInjectOnBind configures the registry to inject the fields of structs embedding AutoInject when they are bound,
+// so InjectFields doesn't need to be called for them manually.
+// If a required dependency isn't bound yet, the injection is deferred to Populate, nothing is injected on bind then.
+// Populate injects the fields of all bound structs again, so dependencies bound in the meantime are picked up.
+// If the injection on bind fails, the binding is rolled back and the bind fails with its error.
+func (r *Registry) AutoInjectOnBind(enabled bool) {
+	r.autoInjectOnBind = enabled
+}
+
+// injectBound injects sources on bind, after they were bound as recorded by bound, if AutoInjectOnBind is enabled.
+// If that fails, the entries are rolled back, unless they were replaced in the meantime.
+func (r *Registry) injectBound(bound []boundEntry, sources ...interface{}) error {
+	if !r.autoInjectOnBind || len(bound) == 0 {
+		return nil
+	}
+	for _, source := range sources {
+		if err := r.injectOnBind(source); err != nil {
+			r.rollback(bound)
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback restores the entries replaced by bound, or removes the bound entries if they didn't replace any.
+func (r *Registry) rollback(bound []boundEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range bound {
+		if r.entries[b.name] != b.entry {
+			continue
+		}
+		if b.replaced == nil {
+			delete(r.entries, b.name)
+		} else {
+			r.entries[b.name] = b.replaced
+		}
+	}
+}
+
+// injectOnBind injects the fields of source, if it is marked with AutoInject and all its required dependencies are bound.
+func (r *Registry) injectOnBind(source interface{}) error {
+	if _, ok := source.(autoInjectable); !ok {
+		return nil
+	}
+	sourceType := reflect.TypeOf(source)
+	if sourceType.Kind() != reflect.Ptr || sourceType.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, dep := range r.fieldDependencies(sourceType.Elem()) {
+		if dep.lazy || dep.optional {
+			continue
+		}
+		if _, _, err := r.bindingName(dep); err != nil {
+			return nil
+		}
+	}
+	return r.InjectFields(source)
+}
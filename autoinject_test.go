@@ -0,0 +1,111 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type AutoInjectedService struct {
+	inject.AutoInject
+	Greeting string `inject:"greeting"`
+}
+
+type PlainService struct {
+	Greeting string `inject:"greeting"`
+}
+
+func TestServiceLocator_AutoInjectOnBind(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.AutoInjectOnBind(true)
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	service := &AutoInjectedService{}
+	plain := &PlainService{}
+	if !assert.NoError(t, registry.Bind(service)) || !assert.NoError(t, registry.Bind(plain)) {
+		return
+	}
+	assert.Equal(t, "Hello", service.Greeting)
+	assert.Equal(t, "", plain.Greeting)
+}
+
+func TestServiceLocator_AutoInjectOnBindDeferred(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.AutoInjectOnBind(true)
+
+	service := &AutoInjectedService{}
+	if !assert.NoError(t, registry.Bind(service)) {
+		return
+	}
+	assert.Equal(t, "", service.Greeting)
+
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, "Hello", service.Greeting)
+}
+
+func TestServiceLocator_AutoInjectOnBindDisabled(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	service := &AutoInjectedService{}
+	if !assert.NoError(t, registry.Bind(service)) {
+		return
+	}
+	assert.Equal(t, "", service.Greeting)
+}
+
+func TestServiceLocator_AutoInjectOnBindAllBindFunctions(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.AutoInjectOnBind(true)
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	named := &AutoInjectedService{}
+	if !assert.NoError(t, registry.BindWithNameAndType("named", reflect.TypeOf(named), named)) {
+		return
+	}
+	grouped := &AutoInjectedService{}
+	if !assert.NoError(t, inject.BindGroup[*AutoInjectedService](registry, "services", grouped)) {
+		return
+	}
+	assert.Equal(t, "Hello", named.Greeting)
+	assert.Equal(t, "Hello", grouped.Greeting)
+}
+
+func TestServiceLocator_AutoInjectOnBindRollback(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.AutoInjectOnBind(true)
+	if !assert.NoError(t, registry.BindWithName("greeting", 42)) {
+		return
+	}
+	previous := &PlainService{}
+	if !assert.NoError(t, registry.BindWithName("service", previous)) {
+		return
+	}
+
+	err := registry.BindWithName("service", &AutoInjectedService{})
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	service, err := registry.GetByName("service", reflect.TypeOf(previous))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, previous, service)
+
+	err = registry.BindWithNameAndType("typed", reflect.TypeOf(&AutoInjectedService{}), &AutoInjectedService{})
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	_, err = registry.GetByName("typed", reflect.TypeOf(&AutoInjectedService{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	_, err = registry.GetByType(reflect.TypeOf(&AutoInjectedService{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
@@ -0,0 +1,36 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"reflect"
+	"testing"
+)
+
+func BenchmarkGetByType(b *testing.B) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+	expectedType := reflect.TypeOf(&SimpleTestInterfaceImpl{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := registry.GetByType(expectedType); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByTypeGeneric(b *testing.B) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&Box[int]{value: 1})
+	registry.MustBind(&Box[string]{value: "one"})
+	expectedType := reflect.TypeOf(&Box[string]{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := registry.GetByType(expectedType); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
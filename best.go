@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetBest resolves all bindings assignable to expectedType and returns the one score rates highest,
+// e.g. to pick an implementation by caller defined criteria. Candidates of the same score are ranked by binding name.
+// Producers are only considered, if they were bound with a declared type. A value bound under several names is
+// only scored once. If there is no candidate, the resolution fails with ErrEntryNotFound.
+func (r *Registry) GetBest(expectedType reflect.Type, score func(candidate interface{}) int) (interface{}, error) {
+	var best interface{}
+	bestScore, found := 0, false
+	for _, name := range r.assignableTo(expectedType) {
+		value, err := r.GetByName(name, expectedType)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %q: %w", name, err)
+		}
+		if candidateScore := score(value); !found || candidateScore > bestScore {
+			best, bestScore, found = value, candidateScore, true
+		}
+	}
+	if !found {
+		return nil, &NotFoundError{Name: expectedType.String()}
+	}
+	return best, nil
+}
+
+// assignableTo returns the sorted names of all bindings assignable to expectedType.
+// Like for implementing, producers are only considered with a declared type and shared values are reported once.
+func (r *Registry) assignableTo(expectedType reflect.Type) []string {
+	entries := uniqueEntries(r.snapshot())
+	var names []string
+	for _, name := range sortedKeys(entries) {
+		entry := entries[name]
+		entryType := entry.declaredType
+		if entryType == nil {
+			if isProducer(entry.source) {
+				continue
+			}
+			entryType = reflect.TypeOf(entry.source)
+		}
+		if entryType != nil && r.isAssignableFrom(expectedType, entryType) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
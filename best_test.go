@@ -0,0 +1,49 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type ScoredImpl struct {
+	name  string
+	score int
+}
+
+func (s *ScoredImpl) Test() string {
+	return s.name
+}
+
+func TestServiceLocator_GetBest(t *testing.T) {
+	registry := inject.NewRegistry()
+	for _, candidate := range []*ScoredImpl{{"low", 1}, {"high", 10}, {"medium", 5}} {
+		if !assert.NoError(t, registry.BindWithName(candidate.name, candidate)) {
+			return
+		}
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	best, err := registry.GetBest(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), func(candidate interface{}) int {
+		return candidate.(*ScoredImpl).score
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "high", best.(SimpleTestInterface).Test())
+}
+
+func TestServiceLocator_GetBestNoCandidates(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	_, err := registry.GetBest(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), func(candidate interface{}) int {
+		return 0
+	})
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
@@ -0,0 +1,102 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindSingleton binds a producer for expectedType, which is invoked only once.
+// Every following resolution returns the cached value until the binding is invalidated.
+func (r *Registry) BindSingleton(expectedType reflect.Type, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(expectedType.String(), &registryEntry{
+		populated:    false,
+		source:       producer,
+		declaredType: expectedType,
+		singleton:    true,
+	})
+}
+
+// BindSingletonWithName binds a producer under name, which is invoked only once.
+// Every following resolution returns the cached value until the binding is invalidated.
+func (r *Registry) BindSingletonWithName(name string, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    producer,
+		singleton: true,
+	})
+}
+
+// BindTransient binds a producer for expectedType, which is invoked on every resolution.
+// It resolves like a producer bound with BindWithType, which isn't cached either, but declares the intent:
+// Invalidate fails with ErrNotCached for it and Describe reports it as transient.
+func (r *Registry) BindTransient(expectedType reflect.Type, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(expectedType.String(), &registryEntry{
+		populated:    false,
+		source:       producer,
+		declaredType: expectedType,
+		transient:    true,
+	})
+}
+
+// Invalidate clears the cached value of the singleton bound under name, so the next resolution produces it again.
+// A value still being produced when Invalidate is called is handed out to its resolution, but not cached.
+// Afterwards all hooks registered with OnInvalidate for name are called.
+// Invalidating a binding bound with BindTransient fails with ErrNotCached, as there is nothing to invalidate.
+func (r *Registry) Invalidate(name string) error {
+	r.mu.Lock()
+	entry, exists := r.entries[name]
+	if !exists {
+		r.mu.Unlock()
+		return &NotFoundError{Name: name}
+	}
+	if entry.transient {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %q is transient", ErrNotCached, name)
+	}
+	entry.cached = nil
+	entry.produced = false
+	entry.generation++
+	hooks := append([]func(name string){}, r.invalidateHooks[name]...)
+	r.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(name)
+	}
+	return nil
+}
+
+// ClearCaches drops the cached values of all singletons, keeping their bindings.
+// Each singleton is produced again on its next resolution. Other than Invalidate, no hooks are called.
+func (r *Registry) ClearCaches() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		entry.cached = nil
+		entry.produced = false
+		entry.generation++
+	}
+}
+
+// CacheStats returns how many resolutions of singletons were served from the cache (hits)
+// and how many had to invoke the producer (misses), e.g. to verify that caching is effective.
+// Resolutions requesting a fresh value bypass the cache and aren't counted.
+func (r *Registry) CacheStats() (hits, misses uint64) {
+	return r.cacheHits.Load(), r.cacheMisses.Load()
+}
+
+// OnInvalidate registers a hook, which is called whenever the binding with the given name gets invalidated.
+// Dependents can use it to drop or refresh their reference to the old value.
+func (r *Registry) OnInvalidate(name string, hook func(name string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invalidateHooks[name] = append(r.invalidateHooks[name], hook)
+}
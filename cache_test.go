@@ -0,0 +1,295 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestServiceLocator_BindSingleton(t *testing.T) {
+	type Config struct {
+		version int
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(&Config{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Config{version: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, err := registry.GetByType(reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := registry.GetByType(reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, produced)
+}
+
+func TestServiceLocator_Invalidate(t *testing.T) {
+	type Config struct {
+		version int
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindSingletonWithName("config", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Config{version: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var invalidated []string
+	registry.OnInvalidate("config", func(name string) {
+		invalidated = append(invalidated, name)
+	})
+
+	first, err := registry.GetByName("config", reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.Invalidate("config")) {
+		return
+	}
+	second, err := registry.GetByName("config", reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, produced)
+	assert.Equal(t, 1, first.(*Config).version)
+	assert.Equal(t, 2, second.(*Config).version)
+	assert.Equal(t, []string{"config"}, invalidated)
+}
+
+func TestServiceLocator_InvalidateWhileProducing(t *testing.T) {
+	type Config struct {
+		version int
+	}
+
+	producing := make(chan struct{})
+	release := make(chan struct{})
+	var produced atomic.Int32
+	registry := inject.NewRegistry()
+	err := registry.BindSingletonWithName("config", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		version := int(produced.Add(1))
+		if version == 1 {
+			close(producing)
+			<-release
+		}
+		return &Config{version: version}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stale := make(chan interface{})
+	go func() {
+		value, _ := registry.GetByName("config", reflect.TypeOf(&Config{}))
+		stale <- value
+	}()
+	<-producing
+	if !assert.NoError(t, registry.Invalidate("config")) {
+		return
+	}
+	close(release)
+	assert.Equal(t, 1, (<-stale).(*Config).version)
+
+	// the value produced before the invalidation isn't cached
+	current, err := registry.GetByName("config", reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, current.(*Config).version)
+}
+
+func TestServiceLocator_BindSingletonConcurrently(t *testing.T) {
+	type Connection struct {
+	}
+
+	var produced atomic.Int32
+	registry := inject.NewRegistry()
+	err := registry.BindSingletonWithName("connection", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced.Add(1)
+		// keep producing, while the other goroutines resolve the singleton
+		time.Sleep(time.Millisecond)
+		return &Connection{}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	start := make(chan struct{})
+	connections := make([]interface{}, 8)
+	var wg sync.WaitGroup
+	for i := range connections {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			connection, err := registry.GetByName("connection", reflect.TypeOf(&Connection{}))
+			assert.NoError(t, err)
+			connections[i] = connection
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), produced.Load())
+	for _, connection := range connections {
+		assert.Same(t, connections[0], connection)
+	}
+}
+
+func TestServiceLocator_InvalidateUnknown(t *testing.T) {
+	registry := inject.NewRegistry()
+	assert.ErrorIs(t, registry.Invalidate("unknown"), inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_InjectFieldsNewInstance(t *testing.T) {
+	type Connection struct {
+		id int
+	}
+
+	type InjectInto struct {
+		Shared *Connection `inject:""`
+		Own    *Connection `inject:",new"`
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(&Connection{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Connection{id: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	shared, err := registry.GetByType(reflect.TypeOf(&Connection{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	assert.Same(t, shared, injectInto.Shared)
+	if assert.NotNil(t, injectInto.Own) {
+		assert.NotSame(t, shared, injectInto.Own)
+		assert.Equal(t, 2, injectInto.Own.id)
+	}
+	assert.Equal(t, 2, produced)
+}
+
+func TestServiceLocator_BindTransient(t *testing.T) {
+	type Config struct {
+		version int
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindTransient(reflect.TypeOf(&Config{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Config{version: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 1; i <= 3; i++ {
+		result, err := registry.GetByType(reflect.TypeOf(&Config{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, i, result.(*Config).version)
+	}
+	assert.Equal(t, 3, produced)
+
+	err = registry.Invalidate("*inject_test.Config")
+	assert.ErrorIs(t, err, inject.ErrNotCached)
+	assert.EqualError(t, err, `binding is never cached: "*inject_test.Config" is transient`)
+}
+
+func TestServiceLocator_ClearCaches(t *testing.T) {
+	type Config struct {
+		version int
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(&Config{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Config{version: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("value", "Hello")) {
+		return
+	}
+
+	first, err := registry.GetByType(reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	registry.ClearCaches()
+	second, err := registry.GetByType(reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	third, err := registry.GetByType(reflect.TypeOf(&Config{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, first.(*Config).version)
+	assert.Equal(t, 2, second.(*Config).version)
+	assert.Same(t, second, third)
+	assert.Equal(t, 2, produced)
+
+	value, err := registry.GetByName("value", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", value)
+}
+
+func TestServiceLocator_CacheStats(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindSingletonWithName("config", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return "config", nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	hits, misses := registry.CacheStats()
+	assert.Equal(t, uint64(0), hits)
+	assert.Equal(t, uint64(0), misses)
+
+	for i := 0; i < 3; i++ {
+		if _, err := registry.GetByName("config", reflect.TypeOf("")); !assert.NoError(t, err) {
+			return
+		}
+	}
+	hits, misses = registry.CacheStats()
+	assert.Equal(t, uint64(2), hits)
+	assert.Equal(t, uint64(1), misses)
+}
@@ -0,0 +1,25 @@
+package inject
+
+// NewChild creates a registry resolving the bindings it lacks from r, e.g. for a module overriding
+// some bindings of the application. Bindings of the child shadow those of r bound under the same name.
+// The child starts with the default configuration, it doesn't inherit the settings of r.
+func (r *Registry) NewChild() *Registry {
+	child := NewRegistry()
+	child.parent = r
+	return child
+}
+
+// ShadowedBindings returns the sorted names of the bindings of the registry, which shadow a binding
+// of its parent or any further ancestor, to audit overrides. It is empty for registries without parent.
+func (r *Registry) ShadowedBindings() []string {
+	var shadowed []string
+	for _, name := range sortedKeys(r.snapshot()) {
+		for ancestor := r.parent; ancestor != nil; ancestor = ancestor.parent {
+			if _, exists := ancestor.entry(name); exists {
+				shadowed = append(shadowed, name)
+				break
+			}
+		}
+	}
+	return shadowed
+}
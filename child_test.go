@@ -0,0 +1,124 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_NewChild(t *testing.T) {
+	parent := inject.NewRegistry()
+	if !assert.NoError(t, parent.BindWithName("greeting", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, parent.Bind(&SimpleTestInterfaceImpl{})) {
+		return
+	}
+	child := parent.NewChild()
+	if !assert.NoError(t, child.BindWithName("greeting", "Hi")) {
+		return
+	}
+
+	greeting, err := child.GetByName("greeting", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hi", greeting)
+
+	greeting, err = parent.GetByName("greeting", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", greeting)
+
+	service, err := child.GetByType(reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, service)
+
+	_, err = child.GetByName("missing", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_ShadowedBindings(t *testing.T) {
+	root := inject.NewRegistry()
+	if !assert.NoError(t, root.BindWithName("greeting", "Hello")) {
+		return
+	}
+	module := root.NewChild().NewChild()
+	if !assert.NoError(t, module.BindWithName("greeting", "Hi")) {
+		return
+	}
+	if !assert.NoError(t, module.BindWithName("farewell", "Bye")) {
+		return
+	}
+
+	assert.Equal(t, []string{"greeting"}, module.ShadowedBindings())
+	assert.Empty(t, root.ShadowedBindings())
+}
+
+func TestServiceLocator_NewChildInterface(t *testing.T) {
+	parent := inject.NewRegistry()
+	if !assert.NoError(t, parent.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+	child := parent.NewChild()
+
+	// the parent resolves the interface through its only implementation
+	service, err := child.GetByType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, service)
+
+	injectInto := &struct {
+		Service SimpleTestInterface `inject:""`
+	}{}
+	if !assert.NoError(t, child.InjectFields(injectInto)) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, injectInto.Service)
+}
+
+func TestServiceLocator_NewChildPlanAndValidate(t *testing.T) {
+	parent := inject.NewRegistry()
+	if !assert.NoError(t, parent.Bind("Hello")) {
+		return
+	}
+	err := inject.Provide[*ProvideRepository](parent, func(dsn string) *ProvideRepository {
+		return &ProvideRepository{dsn: dsn}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	child := parent.NewChild()
+	err = inject.Provide[*ProvideService](child, func(repository *ProvideRepository, greeting string) *ProvideService {
+		return &ProvideService{repository: repository, greeting: greeting}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, child.Validate())
+	plan, err := child.Plan(reflect.TypeOf(&ProvideService{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"string", "*inject_test.ProvideRepository", "*inject_test.ProvideService"}, plan)
+
+	service, err := inject.Get[*ProvideService](child)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", service.repository.dsn)
+
+	err = inject.Provide[*CountingService](child, func(missing *SimpleTestInterfaceImpl) *CountingService {
+		return &CountingService{}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.ErrorIs(t, child.Validate(), inject.ErrEntryNotFound)
+}
@@ -0,0 +1,52 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Resolver resolves bindings by name or by type.
+type Resolver interface {
+	GetByName(name string, expectedType reflect.Type) (interface{}, error)
+	GetByType(expectedType reflect.Type) (interface{}, error)
+}
+
+var (
+	_ Resolver = (*Registry)(nil)
+	_ Resolver = (*CompositeRegistry)(nil)
+)
+
+// CompositeRegistry chains several resolvers, e.g. an application registry in front of a shared library registry.
+// A binding is resolved from the first resolver, which doesn't report ErrEntryNotFound for it.
+type CompositeRegistry struct {
+	resolvers []Resolver
+}
+
+func NewCompositeRegistry(resolvers ...Resolver) *CompositeRegistry {
+	return &CompositeRegistry{
+		resolvers: resolvers,
+	}
+}
+
+func (c *CompositeRegistry) GetByName(name string, expectedType reflect.Type) (interface{}, error) {
+	return c.resolve(func(resolver Resolver) (interface{}, error) {
+		return resolver.GetByName(name, expectedType)
+	})
+}
+
+func (c *CompositeRegistry) GetByType(expectedType reflect.Type) (interface{}, error) {
+	return c.resolve(func(resolver Resolver) (interface{}, error) {
+		return resolver.GetByType(expectedType)
+	})
+}
+
+func (c *CompositeRegistry) resolve(get func(resolver Resolver) (interface{}, error)) (interface{}, error) {
+	for _, resolver := range c.resolvers {
+		value, err := get(resolver)
+		if errors.Is(err, ErrEntryNotFound) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrEntryNotFound
+}
@@ -0,0 +1,71 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestCompositeRegistry_GetByName(t *testing.T) {
+	app := inject.NewRegistry()
+	if !assert.NoError(t, app.BindWithName("Greeting", "Hello App")) {
+		return
+	}
+	library := inject.NewRegistry()
+	if !assert.NoError(t, library.BindWithName("Greeting", "Hello Library")) {
+		return
+	}
+	if !assert.NoError(t, library.BindWithName("Name", "Library")) {
+		return
+	}
+
+	composite := inject.NewCompositeRegistry(app, library)
+
+	greeting, err := composite.GetByName("Greeting", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello App", greeting)
+
+	name, err := composite.GetByName("Name", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Library", name)
+
+	_, err = composite.GetByName("Unknown", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestCompositeRegistry_GetByType(t *testing.T) {
+	library := inject.NewRegistry()
+	err := library.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	composite := inject.NewCompositeRegistry(inject.NewRegistry(), library)
+
+	result, err := composite.GetByType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test1", result.(SimpleTestInterface).Test())
+}
+
+func TestCompositeRegistry_WrongType(t *testing.T) {
+	app := inject.NewRegistry()
+	if !assert.NoError(t, app.BindWithName("Greeting", 42)) {
+		return
+	}
+	library := inject.NewRegistry()
+	if !assert.NoError(t, library.BindWithName("Greeting", "Hello Library")) {
+		return
+	}
+
+	composite := inject.NewCompositeRegistry(app, library)
+
+	_, err := composite.GetByName("Greeting", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+}
@@ -0,0 +1,51 @@
+package inject
+
+import (
+	"context"
+	"reflect"
+)
+
+// ContextProducer is implemented by producers, which need the context of the resolution, e.g. to abort network I/O.
+// Resolutions without a context pass context.Background().
+type ContextProducer interface {
+	Producer
+	ProduceContext(ctx context.Context, source interface{}, expectedType reflect.Type) (interface{}, error)
+}
+
+// GetContext resolves the binding for expectedType like GetByType, passing ctx to context aware producers.
+// If ctx is done before the value is produced, ctx.Err() is returned instead.
+func (r *Registry) GetContext(ctx context.Context, expectedType reflect.Type) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.getByType(expectedType, resolution{ctx: ctx})
+}
+
+// registryKey carries the resolving registry in the context of a production, for RegistryProducer.
+type registryKey struct{}
+
+// produceContext invokes producer, passing ctx if it is a ContextProducer.
+// Production is aborted with ctx.Err(), if ctx is done before or while producing.
+func produceContext(ctx context.Context, producer Producer, source interface{}, expectedType reflect.Type) (interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	var err error
+	registry, hasRegistry := ctx.Value(registryKey{}).(*Registry)
+	if registryProducer, ok := producer.(RegistryProducer); ok && hasRegistry {
+		value, err = registryProducer.ProduceWith(registry, source, expectedType)
+	} else if contextProducer, ok := producer.(ContextProducer); ok {
+		value, err = contextProducer.ProduceContext(ctx, source, expectedType)
+	} else {
+		value, err = producer.Produce(source, expectedType)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return value, err
+}
@@ -0,0 +1,96 @@
+package inject_test
+
+import (
+	"context"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type contextKey struct{}
+
+type ContextAwareProducer struct {
+}
+
+func (p *ContextAwareProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return p.ProduceContext(context.Background(), source, target)
+}
+
+func (p *ContextAwareProducer) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	value, _ := ctx.Value(contextKey{}).(string)
+	return "Hello " + value, nil
+}
+
+func TestServiceLocator_GetContext(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithType(reflect.TypeOf(""), &ContextAwareProducer{})) {
+		return
+	}
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "context")
+	result, err := registry.GetContext(ctx, reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello context", result)
+
+	result, err = registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello ", result)
+}
+
+func TestServiceLocator_GetContextPlainProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithType(reflect.TypeOf(""), constantProducer("Hello"))) {
+		return
+	}
+
+	result, err := registry.GetContext(context.Background(), reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+}
+
+func TestServiceLocator_GetContextCancelled(t *testing.T) {
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return "Hello", nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = registry.GetContext(ctx, reflect.TypeOf(""))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, produced)
+}
+
+func TestServiceLocator_GetContextCancelledWhileProducing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		cancel()
+		return "Hello", nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetContext(ctx, reflect.TypeOf(""))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// the aborted production is not cached
+	result, err := registry.GetContext(context.Background(), reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+}
@@ -0,0 +1,62 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type DeepHandler struct {
+	Greeting string `inject:"greeting"`
+}
+
+func TestServiceLocator_InjectFieldsDeep(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	handlers := map[string]*DeepHandler{"users": {}, "orders": {}}
+	if !assert.NoError(t, registry.BindWithName("handlers", handlers)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("prefixed.users", &DeepHandler{})) {
+		return
+	}
+
+	injectInto := &struct {
+		Handlers map[string]*DeepHandler `inject:"handlers,deep"`
+		Prefixed map[string]*DeepHandler `inject:"prefix:prefixed.,deep"`
+	}{}
+	if !assert.NoError(t, registry.InjectFields(injectInto)) {
+		return
+	}
+	assert.Equal(t, "Hello", injectInto.Handlers["users"].Greeting)
+	assert.Equal(t, "Hello", injectInto.Handlers["orders"].Greeting)
+	assert.Equal(t, "Hello", injectInto.Prefixed["users"].Greeting)
+}
+
+func TestServiceLocator_InjectFieldsDeepMissing(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("handlers", map[string]*DeepHandler{"users": {}})) {
+		return
+	}
+
+	injectInto := &struct {
+		Handlers map[string]*DeepHandler `inject:"handlers,deep"`
+	}{}
+	err := registry.InjectFields(injectInto)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.EqualError(t, err, `field Handlers, key users: object not found: "greeting"`)
+}
+
+func TestServiceLocator_InjectFieldsDeepNoMap(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&DeepHandler{Greeting: "Hello"})) {
+		return
+	}
+
+	injectInto := &struct {
+		Handler *DeepHandler `inject:",deep"`
+	}{}
+	assert.ErrorIs(t, registry.InjectFields(injectInto), inject.ErrInvalidInjectionPoint)
+}
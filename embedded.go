@@ -0,0 +1,73 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResolveEmbedded configures resolutions by type to fall back to embedded structs, if there is no binding for a type.
+// If exactly one bound struct pointer embeds Base, requesting *Base returns a pointer to the embedded Base,
+// sharing its state with the embedding struct, and requesting Base returns a copy of it.
+// Embedded *Base fields are followed, if they aren't nil. Embedding is searched through embedded structs,
+// producers are not considered. Interfaces need no fallback, as embedding structs implement them anyway.
+func (r *Registry) ResolveEmbedded(enabled bool) {
+	r.resolveEmbedded = enabled
+}
+
+// embedded resolves expectedType from the only bound struct pointer embedding it.
+func (r *Registry) embedded(expectedType reflect.Type) (interface{}, error) {
+	entries := uniqueEntries(r.snapshot())
+	names := sortedKeys(entries)
+
+	var embedding []string
+	var value reflect.Value
+	for _, name := range names {
+		source := entries[name].source
+		sourceValue := reflect.ValueOf(source)
+		if isProducer(source) || sourceValue.Kind() != reflect.Ptr || sourceValue.IsNil() || sourceValue.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		if embeddedValue, ok := embeddedField(sourceValue.Elem(), expectedType); ok {
+			embedding = append(embedding, name)
+			value = embeddedValue
+		}
+	}
+
+	switch len(embedding) {
+	case 0:
+		return nil, &NotFoundError{Name: expectedType.String()}
+	case 1:
+		return value.Interface(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s is embedded by %s",
+			ErrAmbiguousBinding, expectedType, strings.Join(embedding, ", "))
+	}
+}
+
+// embeddedField returns the value for expectedType from the embedded fields of structValue, which must be addressable.
+func embeddedField(structValue reflect.Value, expectedType reflect.Type) (reflect.Value, bool) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+		if !field.Anonymous || !fieldValue.CanInterface() {
+			continue
+		}
+
+		switch {
+		case field.Type == expectedType && !(field.Type.Kind() == reflect.Ptr && fieldValue.IsNil()):
+			return fieldValue, true
+		case expectedType.Kind() == reflect.Ptr && field.Type == expectedType.Elem():
+			return fieldValue.Addr(), true
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem() == expectedType && !fieldValue.IsNil():
+			return fieldValue.Elem(), true
+		}
+		if field.Type.Kind() == reflect.Struct {
+			if value, ok := embeddedField(fieldValue, expectedType); ok {
+				return value, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
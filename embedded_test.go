@@ -0,0 +1,64 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type EmbeddedBase struct {
+	Name string
+}
+
+func (b *EmbeddedBase) Test() string {
+	return b.Name
+}
+
+type EmbeddingDerived struct {
+	EmbeddedBase
+	Extra int
+}
+
+func TestServiceLocator_ResolveEmbedded(t *testing.T) {
+	derived := &EmbeddingDerived{EmbeddedBase: EmbeddedBase{Name: "base"}}
+	registry := inject.NewRegistry()
+	registry.MustBind(derived)
+
+	_, err := registry.GetByType(reflect.TypeOf(&EmbeddedBase{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	registry.ResolveEmbedded(true)
+	base, err := registry.GetByType(reflect.TypeOf(&EmbeddedBase{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, &derived.EmbeddedBase, base)
+
+	value, err := registry.GetByType(reflect.TypeOf(EmbeddedBase{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, EmbeddedBase{Name: "base"}, value)
+
+	// interfaces implemented through the embedded struct resolve to the embedding struct
+	service, err := registry.GetByType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, derived, service)
+}
+
+func TestServiceLocator_ResolveEmbeddedAmbiguous(t *testing.T) {
+	type OtherDerived struct {
+		*EmbeddedBase
+	}
+
+	registry := inject.NewRegistry()
+	registry.ResolveEmbedded(true)
+	registry.MustBind(&EmbeddingDerived{})
+	registry.MustBind(&OtherDerived{EmbeddedBase: &EmbeddedBase{}})
+
+	_, err := registry.GetByType(reflect.TypeOf(&EmbeddedBase{}))
+	assert.ErrorIs(t, err, inject.ErrAmbiguousBinding)
+}
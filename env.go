@@ -0,0 +1,43 @@
+package inject
+
+import (
+	"context"
+	"os"
+	"reflect"
+)
+
+// envProducer only produces, while an environment variable has the expected value.
+type envProducer struct {
+	envVar   string
+	envVal   string
+	producer Producer
+}
+
+func (p envProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return p.ProduceContext(context.Background(), source, target)
+}
+
+func (p envProducer) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	if os.Getenv(p.envVar) != p.envVal {
+		return nil, ErrProducerSkip
+	}
+	return produceContext(ctx, p.producer, source, target)
+}
+
+// ProducerIfEnv wraps producer, so it only participates while the environment variable envVar is set to envVal.
+// Otherwise it skips with ErrProducerSkip, so the next producer bound with BindProducerWithPriority is tried,
+// or the binding is treated as absent. The variable is checked on every resolution.
+func ProducerIfEnv(envVar, envVal string, producer Producer) Producer {
+	return envProducer{envVar: envVar, envVal: envVal, producer: producer}
+}
+
+// BindProducerIfEnv adds producer for expectedType, which only participates while the environment variable envVar
+// is set to envVal, e.g. for feature flagged implementations. It is added like BindProducerWithPriority with priority 1,
+// so it takes precedence over producers of the default priority 0 while active.
+// Use ProducerIfEnv with BindProducerWithPriority for other priorities.
+func (r *Registry) BindProducerIfEnv(envVar, envVal string, expectedType reflect.Type, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.BindProducerWithPriority(expectedType, ProducerIfEnv(envVar, envVal, producer), 1)
+}
@@ -0,0 +1,45 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_BindProducerIfEnv(t *testing.T) {
+	registry := inject.NewRegistry()
+	stringType := reflect.TypeOf("")
+	if !assert.NoError(t, registry.BindProducerWithPriority(stringType, constantProducer("smtp"), 0)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindProducerIfEnv("INJECT_TEST_MAILER", "sendgrid", stringType, constantProducer("sendgrid"))) {
+		return
+	}
+
+	t.Setenv("INJECT_TEST_MAILER", "")
+	result, err := registry.GetByType(stringType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "smtp", result)
+
+	t.Setenv("INJECT_TEST_MAILER", "sendgrid")
+	result, err = registry.GetByType(stringType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "sendgrid", result)
+}
+
+func TestServiceLocator_BindProducerIfEnvAbsent(t *testing.T) {
+	registry := inject.NewRegistry()
+	stringType := reflect.TypeOf("")
+	if !assert.NoError(t, registry.BindProducerIfEnv("INJECT_TEST_MAILER", "sendgrid", stringType, constantProducer("sendgrid"))) {
+		return
+	}
+
+	t.Setenv("INJECT_TEST_MAILER", "smtp")
+	_, err := registry.GetByType(stringType)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
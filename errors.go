@@ -0,0 +1,40 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NotFoundError reports a missing binding. It matches ErrEntryNotFound with errors.Is.
+type NotFoundError struct {
+	// Name is the name of the missing binding, for resolutions by type the name of the type.
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s: %q", ErrEntryNotFound, e.Name)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrEntryNotFound
+}
+
+// TypeMismatchError reports a value, which is not assignable to the requested type.
+// It matches ErrInvalidInjectionType with errors.Is.
+type TypeMismatchError struct {
+	// Name is the name of the binding the value was resolved from, empty if it was resolved by type.
+	Name     string
+	Expected reflect.Type
+	Actual   reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("%s: %q of type %s is not assignable to %s", ErrInvalidInjectionType, e.Name, e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("%s: %s is not assignable to %s", ErrInvalidInjectionType, e.Actual, e.Expected)
+}
+
+func (e *TypeMismatchError) Unwrap() error {
+	return ErrInvalidInjectionType
+}
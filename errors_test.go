@@ -0,0 +1,68 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestNotFoundError(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	_, err := registry.GetByName("missing", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	var notFound *inject.NotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, "missing", notFound.Name)
+	}
+	assert.EqualError(t, err, `object not found: "missing"`)
+}
+
+func TestNotFoundError_Wrapped(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	_, err := inject.Get[*SimpleTestInterfaceImpl](registry)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	var notFound *inject.NotFoundError
+	if assert.True(t, errors.As(err, &notFound)) {
+		assert.Equal(t, "*inject_test.SimpleTestInterfaceImpl", notFound.Name)
+	}
+}
+
+func TestTypeMismatchError(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	_, err := registry.GetByName("greeting", reflect.TypeOf(0))
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+
+	var mismatch *inject.TypeMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, "greeting", mismatch.Name)
+		assert.Equal(t, reflect.TypeOf(0), mismatch.Expected)
+		assert.Equal(t, reflect.TypeOf(""), mismatch.Actual)
+	}
+	assert.EqualError(t, err, `invalid injection type: "greeting" of type string is not assignable to int`)
+}
+
+func TestTypeMismatchError_NamedField(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	injectInto := &struct {
+		Writer io.Writer `inject:"greeting"`
+	}{}
+	err := registry.InjectFields(injectInto)
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	assert.EqualError(t, err, `field Writer: invalid injection type: "greeting" of type string is not assignable to io.Writer`)
+	assert.Nil(t, injectInto.Writer)
+}
@@ -0,0 +1,53 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// factoryProducer is the source of bindings bound with BindFactory. It keeps the cleanups of all produced values.
+type factoryProducer struct {
+	fn       func() (value interface{}, cleanup func(), err error)
+	mu       sync.Mutex
+	cleanups []func()
+}
+
+func (f *factoryProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	value, cleanup, err := f.fn()
+	if err != nil {
+		return nil, err
+	}
+	if cleanup != nil {
+		f.mu.Lock()
+		f.cleanups = append(f.cleanups, cleanup)
+		f.mu.Unlock()
+	}
+	return value, nil
+}
+
+// cleanup calls the cleanups of all produced values in reverse order and forgets them.
+func (f *factoryProducer) cleanup() {
+	f.mu.Lock()
+	cleanups := f.cleanups
+	f.cleanups = nil
+	f.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// BindFactory binds fn under name as singleton, which is invoked on the first resolution.
+// Besides the value fn returns a cleanup function, which is called on Shutdown, e.g. to close a connection.
+// This covers resources, which can't implement Disposable. The cleanup may be nil.
+// Cleanups of values dropped by Invalidate or ClearCaches are still called on Shutdown.
+func (r *Registry) BindFactory(name string, fn func() (value interface{}, cleanup func(), err error)) error {
+	if fn == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    &factoryProducer{fn: fn},
+		singleton: true,
+	})
+}
@@ -0,0 +1,61 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+)
+
+// SetFallbackProducer sets a catch-all producer, which is invoked for resolutions without a matching binding,
+// e.g. to construct any requested struct with its zero value. It receives the requested type as target.
+// If it returns ErrProducerSkip, the resolution fails with ErrEntryNotFound as without fallback.
+// Passing nil removes the fallback producer.
+func (r *Registry) SetFallbackProducer(producer Producer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallbackProducer = producer
+}
+
+// fallback resolves name from the parent registry and then invokes the fallback producer,
+// if err reports the binding name itself as missing.
+// Otherwise, e.g. if only a dependency of the binding is missing, err is returned unchanged.
+// Probe resolutions only consult the parent, never the fallback producer.
+func (r *Registry) fallback(name string, expectedType reflect.Type, res resolution, err error) (interface{}, error) {
+	if !isMissing(err, name) {
+		return nil, err
+	}
+	if r.parent != nil {
+		value, parentErr := r.parent.lookup(name, expectedType, res)
+		if parentErr != nil {
+			value, parentErr = r.parent.fallback(name, expectedType, res, parentErr)
+		}
+		if !isMissing(parentErr, name) {
+			return value, parentErr
+		}
+	}
+
+	if res.probe {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	producer := r.fallbackProducer
+	r.mu.RUnlock()
+	if producer == nil {
+		return nil, err
+	}
+
+	value, produceErr := r.invokeProducer(producer, res, expectedType)
+	if errors.Is(produceErr, ErrProducerSkip) {
+		return nil, err
+	}
+	if produceErr != nil {
+		return nil, produceErr
+	}
+	return value, nil
+}
+
+// isMissing reports whether err reports the binding name itself as missing, not just one of its dependencies.
+func isMissing(err error, name string) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound) && notFound.Name == name
+}
@@ -0,0 +1,80 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func zeroStructProducer() inject.Producer {
+	return inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+			return nil, inject.ErrProducerSkip
+		}
+		return reflect.New(target.Elem()).Interface(), nil
+	})
+}
+
+func TestServiceLocator_SetFallbackProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetFallbackProducer(zeroStructProducer())
+
+	result, err := registry.GetByType(reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, result)
+
+	injectInto := &struct {
+		Service *CountingService `inject:""`
+	}{}
+	if !assert.NoError(t, registry.InjectFields(injectInto)) {
+		return
+	}
+	assert.NotNil(t, injectInto.Service)
+}
+
+func TestServiceLocator_SetFallbackProducerSkip(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetFallbackProducer(zeroStructProducer())
+
+	_, err := registry.GetByName("greeting", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_SetFallbackProducerMissingDependency(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetFallbackProducer(zeroStructProducer())
+	if !assert.NoError(t, inject.Provide[*ProvideService](registry, func(greeting string) *ProvideService {
+		return &ProvideService{greeting: greeting}
+	})) {
+		return
+	}
+
+	// the fallback only replaces missing bindings, not bindings with missing dependencies
+	_, err := inject.Get[*ProvideService](registry)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_SetFallbackProducerByConsumer(t *testing.T) {
+	type Logger struct {
+		name string
+	}
+	type Consumer struct {
+		Log *Logger `inject:",byconsumer"`
+	}
+
+	registry := inject.NewRegistry()
+	registry.SetFallbackProducer(zeroStructProducer())
+	if !assert.NoError(t, registry.Bind(&Logger{name: "default"})) {
+		return
+	}
+
+	// the fallback doesn't answer the lookup of the consumer specific binding
+	consumer := Consumer{}
+	if !assert.NoError(t, registry.InjectFields(&consumer)) {
+		return
+	}
+	assert.Equal(t, "default", consumer.Log.name)
+}
@@ -0,0 +1,209 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypeOf returns the reflect.Type of T. Other than reflect.TypeOf it works for interface types as well,
+// e.g. TypeOf[io.Writer]() returns the interface type instead of the type of a value implementing it.
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Get resolves the binding for type T and returns it typed.
+// On failure the zero value of T is returned together with the wrapped error.
+func Get[T any](r *Registry) (T, error) {
+	var zero T
+	expectedType := TypeOf[T]()
+	value, err := r.GetByType(expectedType)
+	if err != nil {
+		return zero, fmt.Errorf("resolving %s: %w", expectedType, err)
+	}
+	return typed[T](value, expectedType.String())
+}
+
+// GetNamed resolves the binding registered under name and returns it typed.
+// On failure the zero value of T is returned together with the wrapped error.
+func GetNamed[T any](r *Registry, name string) (T, error) {
+	var zero T
+	expectedType := TypeOf[T]()
+	value, err := r.GetByName(name, expectedType)
+	if err != nil {
+		return zero, fmt.Errorf("resolving %q: %w", name, err)
+	}
+	return typed[T](value, name)
+}
+
+// GetAs resolves the binding registered under name as whatever type it is bound with and asserts it to Target,
+// e.g. to retrieve a binding stored under a generic name as a specific interface.
+// If the value isn't a Target, the returned error wraps a *TypeMismatchError.
+func GetAs[Target any](r *Registry, name string) (Target, error) {
+	var zero Target
+	boundType := reflect.TypeOf((*interface{})(nil)).Elem()
+	if entry, exists := r.entry(name); exists && entry.declaredType != nil {
+		boundType = entry.declaredType
+	}
+
+	value, err := r.GetByName(name, boundType)
+	if err != nil {
+		return zero, fmt.Errorf("resolving %q: %w", name, err)
+	}
+	result, ok := value.(Target)
+	if !ok {
+		targetType := TypeOf[Target]()
+		return zero, fmt.Errorf("resolving %q: %w", name, &TypeMismatchError{Expected: targetType, Actual: reflect.TypeOf(value)})
+	}
+	return result, nil
+}
+
+// InjectFromTyped resolves the binding for type T like InjectFrom does for a single target,
+// passing caller to producers as source, and returns it typed.
+// On failure the zero value of T is returned together with the error.
+func InjectFromTyped[T any](r *Registry, caller interface{}) (T, error) {
+	var value T
+	if err := r.InjectFrom(caller, &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// Resolve resolves the binding for type T, whether T is a concrete type or an interface.
+// T is treated as interface, if it is instantiated with an interface type, like Resolve[io.Writer],
+// and as concrete type otherwise, like Resolve[*Service] or Resolve[[]string]. The lookup is:
+//   - the binding registered for T, e.g. by Bind or BindWithType
+//   - for interfaces, the only binding implementing T, like GetByType
+//   - otherwise the only binding of any name, e.g. bound with BindWithName, whose type is assignable to T
+//
+// Several matching bindings fail with ErrAmbiguousBinding, no matching binding with ErrEntryNotFound.
+func Resolve[T any](r *Registry) (T, error) {
+	value, err := Get[T](r)
+	expectedType := TypeOf[T]()
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.Name != expectedType.String() {
+		return value, err
+	}
+
+	names := r.assignableTo(expectedType)
+	switch len(names) {
+	case 0:
+		return value, err
+	case 1:
+		return GetNamed[T](r, names[0])
+	default:
+		return value, fmt.Errorf("%w: %s is bound as %s", ErrAmbiguousBinding, expectedType, strings.Join(names, ", "))
+	}
+}
+
+// TryGet resolves the binding for type T like Get, reporting with ok whether there is one.
+// Only a missing binding is reported as not ok, any other failure means misconfiguration and panics.
+func TryGet[T any](r *Registry) (value T, ok bool) {
+	value, err := Get[T](r)
+	if errors.Is(err, ErrEntryNotFound) {
+		return value, false
+	}
+	if err != nil {
+		panic(err)
+	}
+	return value, true
+}
+
+func typed[T any](value interface{}, name string) (T, error) {
+	result, ok := value.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("resolving %q: %w", name, ErrInvalidInjectionType)
+	}
+	return result, nil
+}
+
+// Provide binds the constructor ctor as singleton for type T.
+// ctor must return T, optionally followed by an error. Its parameters are resolved from the registry by type,
+// once T is resolved for the first time.
+func Provide[T any](r *Registry, ctor interface{}) error {
+	expectedType := TypeOf[T]()
+	producer, err := newConstructor(r, ctor, expectedType)
+	if err != nil {
+		return err
+	}
+	return r.bind(expectedType.String(), &registryEntry{
+		populated:    false,
+		source:       producer,
+		declaredType: expectedType,
+		singleton:    true,
+	})
+}
+
+// ProvideScoped binds the constructor ctor for type T to scope like BindScoped, so each Scope constructs its own T.
+// ctor must return T, optionally followed by an error. Its parameters are resolved by type within the Scope,
+// so they may be scoped as well.
+func ProvideScoped[T any](r *Registry, scope string, ctor interface{}) error {
+	expectedType := TypeOf[T]()
+	producer, err := newConstructor(r, ctor, expectedType)
+	if err != nil {
+		return err
+	}
+	return r.BindScoped(scope, expectedType.String(), producer)
+}
+
+// GetImplementing resolves all bindings implementing the interface T, ordered by their names.
+// Producers are only considered, if they were bound with a declared type.
+func GetImplementing[T any](r *Registry) ([]T, error) {
+	iface := TypeOf[T]()
+	if iface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%w: %s is not an interface", ErrInvalidInjectionType, iface)
+	}
+
+	names := r.implementing(iface)
+	values := make([]T, 0, len(names))
+	for _, name := range names {
+		value, err := r.GetByName(name, iface)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %q: %w", name, err)
+		}
+		typedValue, err := typed[T](value, name)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, typedValue)
+	}
+	return values, nil
+}
+
+// BindGroup binds values as typed collection under groupName, e.g. the plugins of a plugin registry.
+// Binding further values to an existing group of the same type appends them.
+func BindGroup[T any](r *Registry, groupName string, values ...T) error {
+	groupType := TypeOf[[]T]()
+	r.mu.Lock()
+	var group []T
+	if entry, exists := r.entries[groupName]; exists {
+		existing, ok := entry.source.([]T)
+		if !ok {
+			r.mu.Unlock()
+			return fmt.Errorf("%w: %q is bound as %T, not as group of %s",
+				ErrInvalidInjectionType, groupName, entry.source, groupType.Elem())
+		}
+		group = append(group, existing...)
+	}
+	group = append(group, values...)
+	bound := r.putBound(groupName, &registryEntry{
+		populated:    false,
+		source:       group,
+		declaredType: groupType,
+	})
+	r.mu.Unlock()
+
+	sources := make([]interface{}, len(values))
+	for i, value := range values {
+		sources[i] = value
+	}
+	return r.injectBound([]boundEntry{bound}, sources...)
+}
+
+// GetGroup resolves the values bound with BindGroup under groupName.
+func GetGroup[T any](r *Registry, groupName string) ([]T, error) {
+	return GetNamed[[]T](r, groupName)
+}
@@ -0,0 +1,368 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := inject.Get[SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test1", result.Test())
+}
+
+func TestTryGet(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+
+	result, ok := inject.TryGet[*SimpleTestInterfaceImpl](registry)
+	assert.True(t, ok)
+	assert.Equal(t, "test1", result.Test())
+}
+
+func TestTryGet_Missing(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	result, ok := inject.TryGet[*SimpleTestInterfaceImpl](registry)
+	assert.False(t, ok)
+	assert.Nil(t, result)
+}
+
+func TestTryGet_Error(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return nil, errors.New("broken")
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.PanicsWithError(t, "resolving string: broken", func() {
+		inject.TryGet[string](registry)
+	})
+}
+
+type Box[T any] struct {
+	value T
+}
+
+func TestGet_GenericInstantiations(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&Box[int]{value: 1})
+	registry.MustBind(&Box[string]{value: "one"})
+
+	intBox, err := inject.Get[*Box[int]](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	stringBox, err := inject.Get[*Box[string]](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 1, intBox.value)
+	assert.Equal(t, "one", stringBox.value)
+}
+
+func TestGetNamed(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("MyCustomName", "Hello")) {
+		return
+	}
+
+	result, err := inject.GetNamed[string](registry, "MyCustomName")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+}
+
+func TestGetNamed_Missing(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	result, err := inject.GetNamed[*SimpleTestInterfaceImpl](registry, "Unknown")
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.Nil(t, result)
+}
+
+func TestGetNamed_WrongType(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("MyCustomName", "Hello")) {
+		return
+	}
+
+	result, err := inject.GetNamed[int](registry, "MyCustomName")
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	assert.Equal(t, 0, result)
+}
+
+func TestGetAs(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	result, err := inject.GetAs[SimpleTestInterface](registry, "service")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test1", result.Test())
+}
+
+func TestGetAs_NotAssignable(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	result, err := inject.GetAs[io.Closer](registry, "service")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+
+	var mismatch *inject.TypeMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, reflect.TypeOf((*io.Closer)(nil)).Elem(), mismatch.Expected)
+		assert.Equal(t, reflect.TypeOf(&SimpleTestInterfaceImpl{}), mismatch.Actual)
+	}
+	assert.EqualError(t, err, `resolving "service": invalid injection type: *inject_test.SimpleTestInterfaceImpl is not assignable to io.Closer`)
+}
+
+func TestGetAs_DeclaredProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithNameTyped("service", reflect.TypeOf(&SimpleTestInterfaceImpl{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return &SimpleTestInterfaceImpl{}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := inject.GetAs[SimpleTestInterface](registry, "service")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, result)
+}
+
+func TestInjectFromTyped(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return "logger for " + inject.SourceType(source).Name(), nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := inject.InjectFromTyped[string](registry, &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "logger for SimpleTestInterfaceImpl", result)
+}
+
+func TestInjectFromTyped_Missing(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	result, err := inject.InjectFromTyped[*SimpleTestInterfaceImpl](registry, nil)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.Nil(t, result)
+}
+
+type ProvideRepository struct {
+	dsn string
+}
+
+type ProvideService struct {
+	repository *ProvideRepository
+	greeting   string
+}
+
+func TestProvide(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("string", "postgres://localhost")) {
+		return
+	}
+
+	constructed := 0
+	err := inject.Provide[*ProvideRepository](registry, func(dsn string) *ProvideRepository {
+		constructed++
+		return &ProvideRepository{dsn: dsn}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, err := inject.Get[*ProvideRepository](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := inject.Get[*ProvideRepository](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "postgres://localhost", first.dsn)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, constructed)
+}
+
+func TestProvide_TwoDependencies(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&ProvideRepository{dsn: "postgres://localhost"})) {
+		return
+	}
+
+	err := inject.Provide[*ProvideService](registry, func(repository *ProvideRepository, greeting string) (*ProvideService, error) {
+		return &ProvideService{repository: repository, greeting: greeting}, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	service, err := inject.Get[*ProvideService](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", service.greeting)
+	assert.Equal(t, "postgres://localhost", service.repository.dsn)
+}
+
+func TestProvide_Error(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := inject.Provide[*ProvideService](registry, func() (*ProvideService, error) {
+		return nil, errors.New("connection refused")
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = inject.Get[*ProvideService](registry)
+	assert.EqualError(t, err, "resolving *inject_test.ProvideService: connection refused")
+}
+
+func TestProvide_MissingDependency(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := inject.Provide[*ProvideRepository](registry, func(dsn string) *ProvideRepository {
+		return &ProvideRepository{dsn: dsn}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = inject.Get[*ProvideRepository](registry)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestProvide_Cycle(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := inject.Provide[*ProvideRepository](registry, func(service *ProvideService) *ProvideRepository {
+		return &ProvideRepository{}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = inject.Provide[*ProvideService](registry, func(repository *ProvideRepository) *ProvideService {
+		return &ProvideService{repository: repository}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = inject.Get[*ProvideRepository](registry)
+	if !assert.ErrorIs(t, err, inject.ErrCircularDependency) {
+		return
+	}
+	assert.Contains(t, err.Error(), "circular dependency: *inject_test.ProvideRepository -> *inject_test.ProvideService -> *inject_test.ProvideRepository")
+}
+
+func TestProvide_InvalidConstructor(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	err := inject.Provide[*ProvideService](registry, func() *ProvideRepository {
+		return nil
+	})
+	assert.ErrorIs(t, err, inject.ErrInvalidProducer)
+
+	err = inject.Provide[*ProvideService](registry, func() (*ProvideService, string) {
+		return nil, ""
+	})
+	assert.ErrorIs(t, err, inject.ErrInvalidProducer)
+
+	err = inject.Provide[*ProvideService](registry, "constructor")
+	assert.ErrorIs(t, err, inject.ErrNotAFunction)
+}
+
+func TestGetImplementing(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("a", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("b", &OtherTestInterfaceImpl{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("c", "Hello")) {
+		return
+	}
+
+	implementations, err := inject.GetImplementing[SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, implementations, 2) {
+		assert.Equal(t, "test1", implementations[0].Test())
+		assert.Equal(t, "test2", implementations[1].Test())
+	}
+}
+
+func TestGetImplementing_NoInterface(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	_, err := inject.GetImplementing[string](registry)
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+}
+
+func TestBindGroup(t *testing.T) {
+	registry := inject.NewRegistry()
+	first, second, third := &SimpleTestInterfaceImpl{}, &OtherTestInterfaceImpl{}, &SimpleTestInterfaceImpl{}
+	if !assert.NoError(t, inject.BindGroup[SimpleTestInterface](registry, "plugins", first, second)) {
+		return
+	}
+	if !assert.NoError(t, inject.BindGroup[SimpleTestInterface](registry, "plugins", third)) {
+		return
+	}
+
+	plugins, err := inject.GetGroup[SimpleTestInterface](registry, "plugins")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, plugins, 3) {
+		assert.Same(t, first, plugins[0])
+		assert.Same(t, second, plugins[1])
+		assert.Same(t, third, plugins[2])
+	}
+}
+
+func TestBindGroup_WrongType(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, inject.BindGroup[string](registry, "plugins", "first")) {
+		return
+	}
+
+	assert.ErrorIs(t, inject.BindGroup[int](registry, "plugins", 1), inject.ErrInvalidInjectionType)
+	_, err := inject.GetGroup[int](registry, "plugins")
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+}
@@ -0,0 +1,78 @@
+package inject
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// goroutineLocalProducer is the source of bindings bound with BindGoroutineLocal.
+// It keeps one instance per goroutine, keyed by the goroutine id.
+type goroutineLocalProducer struct {
+	producer  func() interface{}
+	mu        sync.Mutex
+	instances map[uint64]interface{}
+}
+
+func (p *goroutineLocalProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	id := goroutineID()
+	p.mu.Lock()
+	value, exists := p.instances[id]
+	p.mu.Unlock()
+	if exists {
+		return value, nil
+	}
+
+	value = p.producer()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.instances[id] = value
+	return value, nil
+}
+
+// BindGoroutineLocal binds producer under name, producing a distinct instance for every goroutine resolving it.
+// It is meant for migrating legacy code relying on goroutine local state, prefer passing a context.Context instead.
+//
+// Caveats:
+//   - Go doesn't expose goroutine ids, they are parsed from the stack trace, which is slow compared to other bindings.
+//   - Instances are never released when their goroutine exits, call ReleaseGoroutineLocal before that.
+//     Goroutine ids may be reused by the runtime, a new goroutine may see an instance left behind by an exited one.
+//   - Goroutines started by a goroutine don't inherit its instance, they get their own.
+//   - Instances injected into fields belong to the goroutine doing the injection, e.g. the one calling Populate.
+func (r *Registry) BindGoroutineLocal(name string, producer func() interface{}) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    &goroutineLocalProducer{producer: producer, instances: make(map[uint64]interface{})},
+	})
+}
+
+// ReleaseGoroutineLocal drops the instance of the calling goroutine for the goroutine local binding name,
+// the next resolution on this goroutine produces a new one. Other bindings are ignored.
+func (r *Registry) ReleaseGoroutineLocal(name string) {
+	entry, exists := r.entry(name)
+	if !exists {
+		return
+	}
+	if local, ok := entry.source.(*goroutineLocalProducer); ok {
+		local.mu.Lock()
+		defer local.mu.Unlock()
+		delete(local.instances, goroutineID())
+	}
+}
+
+// goroutineID parses the id of the calling goroutine from the header of its stack trace, like "goroutine 42 [running]:".
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,68 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type GoroutineLocalState struct {
+	Values []string
+}
+
+func TestServiceLocator_BindGoroutineLocal(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindGoroutineLocal("state", func() interface{} {
+		return &GoroutineLocalState{}
+	})) {
+		return
+	}
+
+	stateType := reflect.TypeOf(&GoroutineLocalState{})
+	results := make([][2]interface{}, 2)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			first, err := registry.GetByName("state", stateType)
+			assert.NoError(t, err)
+			second, err := registry.GetByName("state", stateType)
+			assert.NoError(t, err)
+			results[i] = [2]interface{}{first, second}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Same(t, results[0][0], results[0][1])
+	assert.Same(t, results[1][0], results[1][1])
+	assert.NotSame(t, results[0][0], results[1][0])
+}
+
+func TestServiceLocator_ReleaseGoroutineLocal(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindGoroutineLocal("state", func() interface{} {
+		return &GoroutineLocalState{}
+	})) {
+		return
+	}
+
+	stateType := reflect.TypeOf(&GoroutineLocalState{})
+	first, err := registry.GetByName("state", stateType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	registry.ReleaseGoroutineLocal("state")
+	second, err := registry.GetByName("state", stateType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotSame(t, first, second)
+}
+
+func TestServiceLocator_BindGoroutineLocalNil(t *testing.T) {
+	registry := inject.NewRegistry()
+	assert.ErrorIs(t, registry.BindGoroutineLocal("state", nil), inject.ErrInvalidProducer)
+}
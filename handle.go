@@ -0,0 +1,35 @@
+package inject
+
+// BindingHandle references a single registration made by BindWithHandle.
+type BindingHandle struct {
+	registry *Registry
+	name     string
+	id       uint64
+}
+
+// BindWithHandle binds entry under name like BindWithName and returns a handle to release exactly this registration.
+func (r *Registry) BindWithHandle(name string, entry interface{}) (*BindingHandle, error) {
+	registered := &registryEntry{
+		populated: false,
+		source:    entry,
+	}
+	if err := r.bind(name, registered); err != nil {
+		return nil, err
+	}
+	return &BindingHandle{registry: r, name: name, id: registered.id}, nil
+}
+
+// Name returns the name the registration was bound under.
+func (h *BindingHandle) Name() string {
+	return h.name
+}
+
+// Release removes the registration from the registry.
+// If the name was bound again in the meantime, the newer binding is left untouched.
+func (h *BindingHandle) Release() {
+	h.registry.mu.Lock()
+	defer h.registry.mu.Unlock()
+	if entry, exists := h.registry.entries[h.name]; exists && entry.id == h.id {
+		delete(h.registry.entries, h.name)
+	}
+}
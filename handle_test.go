@@ -0,0 +1,45 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestBindingHandle_Release(t *testing.T) {
+	registry := inject.NewRegistry()
+	handle, err := registry.BindWithHandle("Plugin", "Hello")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Plugin", handle.Name())
+
+	result, err := registry.GetByName("Plugin", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+
+	handle.Release()
+	_, err = registry.GetByName("Plugin", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestBindingHandle_ReleaseOverwritten(t *testing.T) {
+	registry := inject.NewRegistry()
+	handle, err := registry.BindWithHandle("Plugin", "Hello")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("Plugin", "Replaced")) {
+		return
+	}
+
+	handle.Release()
+	result, err := registry.GetByName("Plugin", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Replaced", result)
+}
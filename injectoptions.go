@@ -0,0 +1,50 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// InjectOptions maps field names to the bindings injected into them, like the field was annotated with the mapped tag,
+// e.g. {"Log": "logger", "Repository": "", "Cache": ",optional"}. An empty binding name injects by type.
+type InjectOptions map[string]string
+
+// SetInjectOptions registers options for the struct type of prototype, so its fields can be injected without annotations,
+// e.g. for types which can't be modified. InjectFields consults the options in addition to the annotations,
+// a field listed in the options is injected as specified there.
+func (r *Registry) SetInjectOptions(prototype interface{}, options InjectOptions) error {
+	structType := SourceType(prototype)
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a struct", ErrInvalidInjectionPoint, prototype)
+	}
+	for name := range options {
+		if _, exists := structType.FieldByName(name); !exists {
+			return fmt.Errorf("%w: %s has no field %q", ErrInvalidInjectionPoint, structType, name)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.injectOptions == nil {
+		r.injectOptions = make(map[reflect.Type]InjectOptions)
+	}
+	r.injectOptions[structType] = options
+	return nil
+}
+
+// selectorFor returns the FieldSelector for the fields of structType, applying its InjectOptions, if any.
+func (r *Registry) selectorFor(structType reflect.Type) FieldSelector {
+	r.mu.RLock()
+	options := r.injectOptions[structType]
+	r.mu.RUnlock()
+	if options == nil {
+		return r.selectField
+	}
+
+	return func(field reflect.StructField) (string, bool) {
+		if tag, ok := options[field.Name]; ok {
+			return tag, true
+		}
+		return r.selectField(field)
+	}
+}
@@ -0,0 +1,44 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type UntaggedService struct {
+	Greeting string
+	Service  *SimpleTestInterfaceImpl
+	Tagged   string `inject:"greeting"`
+}
+
+func TestServiceLocator_SetInjectOptions(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&SimpleTestInterfaceImpl{})) {
+		return
+	}
+	err := registry.SetInjectOptions(&UntaggedService{}, inject.InjectOptions{"Greeting": "greeting", "Service": ""})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	service := &UntaggedService{}
+	if !assert.NoError(t, registry.InjectFields(service)) {
+		return
+	}
+	assert.Equal(t, "Hello", service.Greeting)
+	assert.NotNil(t, service.Service)
+	assert.Equal(t, "Hello", service.Tagged)
+}
+
+func TestServiceLocator_SetInjectOptionsUnknownField(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.SetInjectOptions(&UntaggedService{}, inject.InjectOptions{"Missing": "greeting"})
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionPoint)
+
+	err = registry.SetInjectOptions("no struct", inject.InjectOptions{})
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionPoint)
+}
@@ -0,0 +1,44 @@
+// Package injecttest provides helpers for tests binding stubs into an inject.Registry.
+// Every helper restores the previous bindings once the test completes.
+package injecttest
+
+import (
+	"github.com/dreske/go-inject"
+	"sync"
+	"testing"
+)
+
+// MustBind binds value by its type like Registry.Bind, failing the test if that isn't possible.
+// The binding is removed again, and a replaced one bound again, when the test completes.
+func MustBind(t testing.TB, r *inject.Registry, value interface{}) {
+	t.Helper()
+	restore := restoreOnCleanup(t, r)
+	if err := r.Bind(value); err != nil {
+		restore()
+		t.Fatalf("binding %T: %v", value, err)
+	}
+}
+
+// WithStub binds value under name, replacing the binding the code under test would usually get.
+// The returned function restores the previous bindings right away, otherwise they are restored when the test completes.
+func WithStub(t testing.TB, r *inject.Registry, name string, value interface{}) (restore func()) {
+	t.Helper()
+	restore = restoreOnCleanup(t, r)
+	if err := r.BindWithName(name, value); err != nil {
+		restore()
+		t.Fatalf("stubbing %q: %v", name, err)
+	}
+	return restore
+}
+
+// restoreOnCleanup snapshots the bindings of r and returns a function restoring them, which runs on cleanup at the latest.
+// The snapshot is restored only once, so bindings made after an explicit restore are not dropped on cleanup.
+func restoreOnCleanup(t testing.TB, r *inject.Registry) func() {
+	snapshot := r.Snapshot()
+	var once sync.Once
+	restore := func() {
+		once.Do(func() { r.Restore(snapshot) })
+	}
+	t.Cleanup(restore)
+	return restore
+}
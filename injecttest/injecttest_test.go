@@ -0,0 +1,82 @@
+package injecttest_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/dreske/go-inject/injecttest"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type Mailer struct {
+	name string
+}
+
+func TestMustBind(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	t.Run("bound", func(t *testing.T) {
+		injecttest.MustBind(t, registry, &Mailer{name: "stub"})
+
+		mailer, err := registry.GetByType(reflect.TypeOf(&Mailer{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "stub", mailer.(*Mailer).name)
+	})
+
+	_, err := registry.GetByType(reflect.TypeOf(&Mailer{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestWithStub(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("mailer", &Mailer{name: "smtp"})) {
+		return
+	}
+
+	t.Run("stubbed", func(t *testing.T) {
+		injecttest.WithStub(t, registry, "mailer", &Mailer{name: "stub"})
+
+		mailer, err := registry.GetByName("mailer", reflect.TypeOf(&Mailer{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "stub", mailer.(*Mailer).name)
+	})
+
+	mailer, err := registry.GetByName("mailer", reflect.TypeOf(&Mailer{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "smtp", mailer.(*Mailer).name)
+}
+
+func TestWithStubRestore(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("mailer", &Mailer{name: "smtp"})) {
+		return
+	}
+
+	t.Run("restored", func(t *testing.T) {
+		restore := injecttest.WithStub(t, registry, "mailer", &Mailer{name: "stub"})
+		restore()
+
+		mailer, err := registry.GetByName("mailer", reflect.TypeOf(&Mailer{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "smtp", mailer.(*Mailer).name)
+
+		// bindings made after restoring explicitly survive the cleanup
+		if !assert.NoError(t, registry.BindWithName("logger", "stdout")) {
+			return
+		}
+	})
+
+	logger, err := registry.GetByName("logger", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "stdout", logger)
+}
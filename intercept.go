@@ -0,0 +1,54 @@
+package inject
+
+// ResolveInterceptor is called with every resolved value and the name of its binding, before the value is
+// returned or assigned. It may replace the value, e.g. by a proxy adding tracing, or fail the resolution.
+type ResolveInterceptor func(name string, value interface{}) (interface{}, error)
+
+// SetResolveInterceptor replaces the interceptors applied to resolved values.
+// The interceptors are chained in the passed order, each receiving the value returned by the previous one.
+// Calling it without interceptors removes them.
+func (r *Registry) SetResolveInterceptor(interceptors ...ResolveInterceptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interceptors = append([]ResolveInterceptor(nil), interceptors...)
+}
+
+// intercept passes value through the chain of interceptors.
+func (r *Registry) intercept(name string, value interface{}) (interface{}, error) {
+	r.mu.RLock()
+	interceptors := r.interceptors
+	r.mu.RUnlock()
+
+	for _, interceptor := range interceptors {
+		err := r.protect(func() error {
+			var err error
+			value, err = interceptor(name, value)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// SetTargetInterceptor sets an interceptor, which is called with the target at the start of InjectFields and
+// with each target of InjectFrom. The value returned by the interceptor is what gets injected into,
+// so it may substitute or wrap the target, e.g. for testing or proxying. Passing nil removes the interceptor.
+func (r *Registry) SetTargetInterceptor(interceptor func(target interface{}) interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targetInterceptor = interceptor
+}
+
+// interceptTarget returns the target to inject into in place of target.
+func (r *Registry) interceptTarget(target interface{}) interface{} {
+	r.mu.RLock()
+	interceptor := r.targetInterceptor
+	r.mu.RUnlock()
+
+	if interceptor == nil {
+		return target
+	}
+	return interceptor(target)
+}
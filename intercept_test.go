@@ -0,0 +1,106 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type TracingProxy struct {
+	target SimpleTestInterface
+	calls  *[]string
+}
+
+func (p *TracingProxy) Test() string {
+	*p.calls = append(*p.calls, "Test")
+	return p.target.Test()
+}
+
+func TestServiceLocator_SetResolveInterceptor(t *testing.T) {
+	type InjectInto struct {
+		Service SimpleTestInterface `inject:"service"`
+	}
+
+	var calls, names []string
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+	registry.SetResolveInterceptor(
+		func(name string, value interface{}) (interface{}, error) {
+			names = append(names, name)
+			return value, nil
+		},
+		func(name string, value interface{}) (interface{}, error) {
+			if service, ok := value.(SimpleTestInterface); ok {
+				return &TracingProxy{target: service, calls: &calls}, nil
+			}
+			return value, nil
+		},
+	)
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	if !assert.IsType(t, &TracingProxy{}, injectInto.Service) {
+		return
+	}
+	assert.Equal(t, "test1", injectInto.Service.Test())
+	assert.Equal(t, []string{"Test"}, calls)
+	assert.Equal(t, []string{"service"}, names)
+}
+
+func TestServiceLocator_SetResolveInterceptorError(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind("Hello")
+	registry.SetResolveInterceptor(func(name string, value interface{}) (interface{}, error) {
+		return nil, errors.New("intercepted")
+	})
+
+	_, err := registry.GetByType(reflect.TypeOf(""))
+	assert.EqualError(t, err, "intercepted")
+
+	registry.SetResolveInterceptor()
+	result, err := registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+}
+
+func TestServiceLocator_SetTargetInterceptor(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind("Hi")) {
+		return
+	}
+
+	substitute := &PlainService{}
+	var intercepted []interface{}
+	registry.SetTargetInterceptor(func(target interface{}) interface{} {
+		intercepted = append(intercepted, target)
+		if _, ok := target.(*PlainService); ok {
+			return substitute
+		}
+		return target
+	})
+
+	original := &PlainService{}
+	if !assert.NoError(t, registry.InjectFields(original)) {
+		return
+	}
+	assert.Equal(t, "", original.Greeting)
+	assert.Equal(t, "Hello", substitute.Greeting)
+
+	var greeting string
+	if !assert.NoError(t, registry.InjectFrom(nil, &greeting)) {
+		return
+	}
+	assert.Equal(t, "Hi", greeting)
+	assert.Equal(t, []interface{}{original, &greeting}, intercepted)
+}
@@ -0,0 +1,188 @@
+package inject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// NameOf returns the name of the binding value is registered under, comparing pointers by identity.
+// Values produced by singletons are found as well. If value is registered under several names, the lowest name is returned.
+func (r *Registry) NameOf(value interface{}) (string, bool) {
+	if value == nil || !reflect.ValueOf(value).Comparable() {
+		return "", false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var names []string
+	for name, entry := range r.entries {
+		if matches(entry.source, value) || (entry.produced && matches(entry.cached, value)) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+// Range calls fn for every binding with its name and bound source, in no particular order, until fn returns false.
+// Bindings are iterated under the read lock without copying them, so fn must not bind or release bindings.
+func (r *Registry) Range(fn func(name string, source interface{}) bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name, entry := range r.entries {
+		if !fn(name, entry.source) {
+			return
+		}
+	}
+}
+
+// IsProducer reports whether the binding registered under name is a producer, which creates its value on resolution,
+// and whether the binding exists at all.
+func (r *Registry) IsProducer(name string) (producer bool, exists bool) {
+	entry, exists := r.entry(name)
+	if !exists {
+		return false, false
+	}
+	return isProducer(entry.source), true
+}
+
+// matches compares a bound value to value, which must be comparable.
+// Values of a comparable type may still be uncomparable, e.g. structs holding a slice in an interface field.
+func matches(bound, value interface{}) bool {
+	if bound == nil || reflect.TypeOf(bound) != reflect.TypeOf(value) || !reflect.ValueOf(bound).Comparable() {
+		return false
+	}
+	return bound == value
+}
+
+// BindingDescription describes a single binding, see Describe.
+// The JSON field names are part of the API and kept stable.
+type BindingDescription struct {
+	// Name is the name the binding is registered under.
+	Name string `json:"name"`
+	// Type is the concrete type of the bound value or producer.
+	Type string `json:"type"`
+	// DeclaredType is the type the binding was bound with, empty if bound by name only.
+	DeclaredType string `json:"declaredType,omitempty"`
+	// Producer is true, if the value is created by a producer on resolution.
+	Producer bool `json:"producer"`
+	// Singleton is true, if the produced value is cached.
+	Singleton bool `json:"singleton"`
+	// Transient is true, if the producer is invoked on every resolution.
+	Transient bool `json:"transient"`
+	// Cached is true, if a singleton has produced its value already.
+	Cached bool `json:"cached"`
+	// Dependencies are the names of the explicitly declared dependencies, see BindWithDeps.
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// ListBindings returns the names of all bindings, sorted.
+func (r *Registry) ListBindings() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedKeys(r.entries)
+}
+
+// Describe returns a description of every binding, ordered by name.
+func (r *Registry) Describe() []BindingDescription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	descriptions := make([]BindingDescription, 0, len(r.entries))
+	for _, name := range sortedKeys(r.entries) {
+		entry := r.entries[name]
+		description := BindingDescription{
+			Name:         name,
+			Type:         fmt.Sprintf("%T", entry.source),
+			Producer:     isProducer(entry.source),
+			Singleton:    entry.singleton,
+			Transient:    entry.transient,
+			Cached:       entry.produced,
+			Dependencies: append([]string(nil), entry.deps...),
+		}
+		if entry.declaredType != nil {
+			description.DeclaredType = entry.declaredType.String()
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// DescribeJSON returns Describe as JSON array, e.g. to expose the registry on an admin endpoint.
+func (r *Registry) DescribeJSON() ([]byte, error) {
+	return json.Marshal(r.Describe())
+}
+
+// Fingerprint returns a hash of the sorted binding names and the concrete types bound under them.
+// It is stable across runs, so tests can assert it to catch unintended changes of the wiring.
+func (r *Registry) Fingerprint() string {
+	hash := sha256.New()
+	for _, description := range r.Describe() {
+		fmt.Fprintf(hash, "%s\x00%s\n", description.Name, description.Type)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// InterfacesOf returns the interfaces the binding registered under name satisfies, sorted by name.
+// As Go can't enumerate all interfaces, only the interfaces known to the registry are considered: the declared types
+// of all bindings, e.g. bound with BindImplemented, the types of the annotated fields of bound structs and the
+// additionally passed candidates. Producers are checked by their declared type, if any.
+func (r *Registry) InterfacesOf(name string, candidates ...reflect.Type) []reflect.Type {
+	entry, exists := r.entry(name)
+	if !exists {
+		return nil
+	}
+	boundType := entry.declaredType
+	if !isProducer(entry.source) {
+		boundType = reflect.TypeOf(entry.source)
+	}
+	if boundType == nil {
+		return nil
+	}
+
+	var ifaces []reflect.Type
+	seen := make(map[reflect.Type]bool)
+	for _, candidate := range append(r.knownInterfaces(), candidates...) {
+		if candidate.Kind() == reflect.Interface && !seen[candidate] && boundType.Implements(candidate) {
+			seen[candidate] = true
+			ifaces = append(ifaces, candidate)
+		}
+	}
+	sort.Slice(ifaces, func(i, j int) bool {
+		return ifaces[i].String() < ifaces[j].String()
+	})
+	return ifaces
+}
+
+// knownInterfaces returns the interfaces bindings are declared as or requested as by bound structs.
+func (r *Registry) knownInterfaces() []reflect.Type {
+	var ifaces []reflect.Type
+	for _, entry := range r.snapshot() {
+		if entry.declaredType != nil && entry.declaredType.Kind() == reflect.Interface {
+			ifaces = append(ifaces, entry.declaredType)
+		}
+		for _, dep := range r.dependencies(entry) {
+			if dep.expectedType != nil && dep.expectedType.Kind() == reflect.Interface {
+				ifaces = append(ifaces, dep.expectedType)
+			}
+		}
+	}
+	return ifaces
+}
+
+// sortedKeys returns the keys of values in ascending order. Everything listing bindings iterates them this way,
+// so the output is deterministic, e.g. for golden file tests.
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_NameOf(t *testing.T) {
+	type Service struct {
+		name string
+	}
+
+	registry := inject.NewRegistry()
+	service := &Service{name: "service"}
+	if !assert.NoError(t, registry.BindWithName("MyService", service)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("Greeting", []string{"Hello"})) {
+		return
+	}
+
+	name, ok := registry.NameOf(service)
+	assert.True(t, ok)
+	assert.Equal(t, "MyService", name)
+
+	_, ok = registry.NameOf(&Service{name: "service"})
+	assert.False(t, ok)
+
+	_, ok = registry.NameOf([]string{"Hello"})
+	assert.False(t, ok)
+}
+
+func TestServiceLocator_NameOfUncomparableValue(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("holder", ValueHolder{V: []int{1}})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("number", ValueHolder{V: 1})) {
+		return
+	}
+
+	_, ok := registry.NameOf(ValueHolder{V: []int{1}})
+	assert.False(t, ok)
+
+	name, ok := registry.NameOf(ValueHolder{V: 1})
+	assert.True(t, ok)
+	assert.Equal(t, "number", name)
+}
+
+func TestServiceLocator_NameOfSingleton(t *testing.T) {
+	type Service struct {
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(&Service{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return &Service{}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	service, err := registry.GetByType(reflect.TypeOf(&Service{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	name, ok := registry.NameOf(service)
+	assert.True(t, ok)
+	assert.Equal(t, "*inject_test.Service", name)
+}
+
+func TestServiceLocator_IsProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("value", "Hello")) {
+		return
+	}
+	err := registry.BindWithName("producer", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return "Hello", nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	producer, exists := registry.IsProducer("value")
+	assert.False(t, producer)
+	assert.True(t, exists)
+
+	producer, exists = registry.IsProducer("producer")
+	assert.True(t, producer)
+	assert.True(t, exists)
+
+	producer, exists = registry.IsProducer("unknown")
+	assert.False(t, producer)
+	assert.False(t, exists)
+}
+
+func TestServiceLocator_DescribeJSON(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithDeps("api", "Hello", "config")) {
+		return
+	}
+	err := registry.BindSingletonWithName("config", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return "config", nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+
+	result, err := registry.DescribeJSON()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.JSONEq(t, `[
+		{"name": "*inject_test.SimpleTestInterfaceImpl", "type": "*inject_test.SimpleTestInterfaceImpl",
+			"declaredType": "*inject_test.SimpleTestInterfaceImpl", "producer": false, "singleton": false, "transient": false, "cached": false},
+		{"name": "api", "type": "string", "producer": false, "singleton": false, "transient": false, "cached": false,
+			"dependencies": ["config"]},
+		{"name": "config", "type": "inject.ProducerFunc", "producer": true, "singleton": true, "transient": false, "cached": false}
+	]`, string(result))
+}
+
+func TestServiceLocator_Fingerprint(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	fingerprint := registry.Fingerprint()
+	assert.Len(t, fingerprint, 64)
+
+	other := inject.NewRegistry()
+	if !assert.NoError(t, other.BindWithName("greeting", "Hi")) {
+		return
+	}
+	other.MustBind(&SimpleTestInterfaceImpl{})
+	assert.Equal(t, fingerprint, other.Fingerprint())
+
+	registry.MustBind(&OtherTestInterfaceImpl{})
+	assert.NotEqual(t, fingerprint, registry.Fingerprint())
+}
+
+func TestServiceLocator_Range(t *testing.T) {
+	registry := inject.NewRegistry()
+	for _, name := range []string{"a", "b", "c"} {
+		if !assert.NoError(t, registry.BindWithName(name, name)) {
+			return
+		}
+	}
+
+	var names []string
+	registry.Range(func(name string, source interface{}) bool {
+		assert.Equal(t, name, source)
+		names = append(names, name)
+		return true
+	})
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, names)
+
+	visited := 0
+	registry.Range(func(name string, source interface{}) bool {
+		visited++
+		return false
+	})
+	assert.Equal(t, 1, visited)
+}
+
+func TestServiceLocator_InterfacesOf(t *testing.T) {
+	registry := inject.NewRegistry()
+	simpleType := reflect.TypeOf((*SimpleTestInterface)(nil)).Elem()
+	writerType := reflect.TypeOf((*io.Writer)(nil)).Elem()
+	closerType := reflect.TypeOf((*io.Closer)(nil)).Elem()
+	if !assert.NoError(t, registry.BindImplemented(&TripleInterfaceImpl{}, simpleType, writerType)) {
+		return
+	}
+
+	name := reflect.TypeOf(&TripleInterfaceImpl{}).String()
+	assert.Equal(t, []reflect.Type{simpleType, writerType}, registry.InterfacesOf(name))
+	assert.Equal(t, []reflect.Type{simpleType, closerType, writerType}, registry.InterfacesOf(name, closerType))
+	assert.Nil(t, registry.InterfacesOf("missing"))
+}
+
+func TestServiceLocator_ListBindings(t *testing.T) {
+	registry := inject.NewRegistry()
+	for _, name := range []string{"metrics", "api", "database", "cache"} {
+		if !assert.NoError(t, registry.BindWithName(name, name)) {
+			return
+		}
+	}
+
+	assert.Equal(t, []string{"api", "cache", "database", "metrics"}, registry.ListBindings())
+
+	var described []string
+	for _, description := range registry.Describe() {
+		described = append(described, description.Name)
+	}
+	assert.Equal(t, registry.ListBindings(), described)
+}
@@ -0,0 +1,201 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Invoke calls fn with its parameters resolved from the registry by type and returns the results of the call.
+func (r *Registry) Invoke(fn interface{}) ([]interface{}, error) {
+	return r.invoke(fn, resolution{})
+}
+
+func (r *Registry) invoke(fn interface{}, res resolution) ([]interface{}, error) {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, ErrNotAFunction
+	}
+
+	args, err := r.resolveArguments(fnValue.Type(), res)
+	if err != nil {
+		return nil, err
+	}
+	return call(fnValue, args), nil
+}
+
+// Bound works like Invoke, but returns a closure calling fn instead of calling it right away.
+// Parameters bound directly to a value are resolved once by Bound, later rebinding them doesn't affect the closure.
+// All other parameters, e.g. bound to producers, are resolved on every call.
+func (r *Registry) Bound(fn interface{}) (func() ([]interface{}, error), error) {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, ErrNotAFunction
+	}
+
+	fnType := fnValue.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	var pending []int
+	for i := range args {
+		entry, exists := r.entry(fnType.In(i).String())
+		if !exists || isProducer(entry.source) {
+			pending = append(pending, i)
+			continue
+		}
+		arg, err := r.resolveArgument(fnType, i, resolution{})
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+
+	return func() ([]interface{}, error) {
+		callArgs := append([]reflect.Value(nil), args...)
+		for _, i := range pending {
+			arg, err := r.resolveArgument(fnType, i, resolution{})
+			if err != nil {
+				return nil, err
+			}
+			callArgs[i] = arg
+		}
+		return call(fnValue, callArgs), nil
+	}, nil
+}
+
+// resolveArguments resolves a value for every parameter of fnType.
+func (r *Registry) resolveArguments(fnType reflect.Type, res resolution) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := range args {
+		arg, err := r.resolveArgument(fnType, i, res)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// resolveArgument resolves the value for parameter i of fnType.
+func (r *Registry) resolveArgument(fnType reflect.Type, i int, res resolution) (reflect.Value, error) {
+	paramType := fnType.In(i)
+	value, err := r.getByType(paramType, res)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("resolving parameter %d of type %s: %w", i, paramType, err)
+	}
+	return reflect.ValueOf(value), nil
+}
+
+func call(fnValue reflect.Value, args []reflect.Value) []interface{} {
+	var results []reflect.Value
+	if fnValue.Type().IsVariadic() {
+		results = fnValue.CallSlice(args)
+	} else {
+		results = fnValue.Call(args)
+	}
+
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		values[i] = result.Interface()
+	}
+	return values
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// constructor produces values by calling a function with its parameters resolved from the registry.
+// The function returns the value, optionally followed by an error.
+type constructor struct {
+	registry *Registry
+	fn       reflect.Value
+}
+
+// newConstructor validates that fn is a function returning a value assignable to resultType, optionally followed by an error.
+func newConstructor(r *Registry, fn interface{}, resultType reflect.Type) (*constructor, error) {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, ErrNotAFunction
+	}
+
+	fnType := fnValue.Type()
+	switch {
+	case fnType.NumOut() == 0 || fnType.NumOut() > 2:
+		return nil, fmt.Errorf("%w: constructor must return a value and optionally an error", ErrInvalidProducer)
+	case !fnType.Out(0).AssignableTo(resultType):
+		return nil, fmt.Errorf("%w: constructor returns %s instead of %s", ErrInvalidProducer, fnType.Out(0), resultType)
+	case fnType.NumOut() == 2 && fnType.Out(1) != errorType:
+		return nil, fmt.Errorf("%w: constructor returns %s instead of error", ErrInvalidProducer, fnType.Out(1))
+	}
+	return &constructor{registry: r, fn: fnValue}, nil
+}
+
+func (c *constructor) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return c.ProduceContext(context.Background(), source, target)
+}
+
+// ProduceContext resolves the parameters within the context of the resolution producing the constructor.
+func (c *constructor) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	res := resolution{ctx: ctx, trace: traceFrom(ctx), constructing: constructingFrom(ctx), scope: scopeFrom(ctx)}
+	args, err := c.registry.resolveArguments(c.fn.Type(), res)
+	if err != nil {
+		return nil, err
+	}
+
+	results := c.fn.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, results[1].Interface().(error)
+	}
+	return results[0].Interface(), nil
+}
+
+// constructionPath lists the constructor entries in flight, innermost first.
+type constructionPath struct {
+	entry *registryEntry
+	name  string
+	outer *constructionPath
+}
+
+type constructingKey struct{}
+
+func constructingFrom(ctx context.Context) *constructionPath {
+	path, _ := ctx.Value(constructingKey{}).(*constructionPath)
+	return path
+}
+
+// construct adds entry to the constructors in flight, if it is bound to a constructor, scoped or not.
+// It fails with ErrCircularDependency, if the constructor is in flight already.
+func (res resolution) construct(entry *registryEntry, name string) (resolution, error) {
+	source := entry.source
+	if scoped, ok := source.(scopedProducer); ok {
+		source = scoped.producer
+	}
+	if _, ok := source.(*constructor); !ok {
+		return res, nil
+	}
+	if res.constructing.contains(entry) {
+		return res, fmt.Errorf("%w: %s", ErrCircularDependency, res.constructing.cycle(entry, name))
+	}
+	res.constructing = &constructionPath{entry: entry, name: name, outer: res.constructing}
+	return res, nil
+}
+
+func (p *constructionPath) contains(entry *registryEntry) bool {
+	for ; p != nil; p = p.outer {
+		if p.entry == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// cycle formats the names from the outermost construction of entry to name, which requests entry again.
+func (p *constructionPath) cycle(entry *registryEntry, name string) string {
+	names := []string{name}
+	for ; p != nil; p = p.outer {
+		names = append([]string{p.name}, names...)
+		if p.entry == entry {
+			break
+		}
+	}
+	return strings.Join(names, " -> ")
+}
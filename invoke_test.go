@@ -0,0 +1,94 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestServiceLocator_Invoke(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	results, err := registry.Invoke(func(greeting string, service SimpleTestInterface) (string, error) {
+		return greeting + " " + service.Test(), nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []interface{}{"Hello test1", nil}, results)
+}
+
+func TestServiceLocator_InvokeMissingParameter(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+
+	called := false
+	_, err := registry.Invoke(func(greeting string, count int) {
+		called = true
+	})
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.EqualError(t, err, `resolving parameter 1 of type int: object not found: "int"`)
+	assert.False(t, called)
+}
+
+func TestServiceLocator_InvokeNoFunction(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	_, err := registry.Invoke("Hello")
+	assert.Equal(t, inject.ErrNotAFunction, err)
+}
+
+func TestServiceLocator_Bound(t *testing.T) {
+	produced := 0
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+	err := registry.BindWithType(reflect.TypeOf(0), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return produced, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fn, err := registry.Bound(func(greeting string, count int) string {
+		return greeting + " " + strconv.Itoa(count)
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 0, produced)
+
+	for i := 1; i <= 3; i++ {
+		results, err := fn()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []interface{}{"Hello " + strconv.Itoa(i)}, results)
+	}
+}
+
+func TestServiceLocator_BoundMissingParameter(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	fn, err := registry.Bound(func(greeting string) {})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = fn()
+	assert.EqualError(t, err, `resolving parameter 0 of type string: object not found: "string"`)
+}
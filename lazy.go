@@ -0,0 +1,90 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy defers the resolution of a field until its first access. Go can't intercept field access,
+// so lazy fields are declared as *Lazy[T] and accessed with Get:
+//
+//	type Handler struct {
+//	    Mailer *inject.Lazy[Mailer] `inject:""`
+//	}
+//
+// The field is injected with a handle, which resolves T by type, or the name given in the tag, on the first Get.
+// Lazy fields don't count as dependencies for the init order of Populate, so they may break up cycles.
+type Lazy[T any] struct {
+	mu       sync.Mutex
+	registry *Registry
+	name     string
+	source   interface{}
+	resolved bool
+	value    T
+}
+
+// Get resolves the value on the first call and returns it on every following call.
+// Failed resolutions are retried on the next call.
+func (l *Lazy[T]) Get() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var zero T
+	if l.resolved {
+		return l.value, nil
+	}
+	if l.registry == nil {
+		return zero, fmt.Errorf("%w: lazy field was not injected", ErrInvalidInjectionPoint)
+	}
+
+	expectedType := TypeOf[T]()
+	var value interface{}
+	var err error
+	name := l.name
+	if name == "" {
+		name = expectedType.String()
+		value, err = l.registry.getByType(expectedType, resolution{source: l.source})
+	} else {
+		value, err = l.registry.getByName(name, expectedType, resolution{source: l.source})
+	}
+	if err != nil {
+		return zero, fmt.Errorf("resolving %q: %w", name, err)
+	}
+	typedValue, err := typed[T](value, name)
+	if err != nil {
+		return zero, err
+	}
+
+	l.value, l.resolved = typedValue, true
+	return typedValue, nil
+}
+
+func (l *Lazy[T]) injectLazy(r *Registry, name string, source interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.registry, l.name, l.source = r, name, source
+}
+
+// lazyInjectable is implemented by *Lazy[T] of any T.
+type lazyInjectable interface {
+	injectLazy(r *Registry, name string, source interface{})
+}
+
+var lazyInjectableType = reflect.TypeOf((*lazyInjectable)(nil)).Elem()
+
+func isLazy(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.Ptr && fieldType.Implements(lazyInjectableType)
+}
+
+// lazyValue returns a new *Lazy[T] for a field of fieldType, resolving name from source.
+func (r *Registry) lazyValue(fieldType reflect.Type, name string, source interface{}) reflect.Value {
+	lazy := reflect.New(fieldType.Elem())
+	lazy.Interface().(lazyInjectable).injectLazy(r, name, source)
+	return lazy
+}
+
+// lazyType returns T of a *Lazy[T] fieldType, using the return type of its Get method.
+func lazyType(fieldType reflect.Type) reflect.Type {
+	get, _ := fieldType.MethodByName("Get")
+	return get.Type.Out(0)
+}
@@ -0,0 +1,81 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_InjectFieldsLazy(t *testing.T) {
+	type InjectInto struct {
+		Service *inject.Lazy[SimpleTestInterface] `inject:""`
+		Named   *inject.Lazy[string]              `inject:"greeting"`
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &SimpleTestInterfaceImpl{}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	assert.Equal(t, 0, produced)
+
+	service, err := injectInto.Service.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test1", service.Test())
+	again, err := injectInto.Service.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, service, again)
+	assert.Equal(t, 1, produced)
+
+	_, err = injectInto.Named.Get()
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	greeting, err := injectInto.Named.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", greeting)
+}
+
+type LazyCycleA struct {
+	B *LazyCycleB `inject:""`
+}
+
+type LazyCycleB struct {
+	A *inject.Lazy[*LazyCycleA] `inject:""`
+}
+
+func TestServiceLocator_PlanLazyCycle(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&LazyCycleA{})
+	registry.MustBind(&LazyCycleB{})
+
+	plan, err := registry.Plan(reflect.TypeOf(&LazyCycleA{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"*inject_test.LazyCycleB", "*inject_test.LazyCycleA"}, plan)
+	assert.NoError(t, registry.Validate())
+}
+
+func TestLazy_NotInjected(t *testing.T) {
+	lazy := &inject.Lazy[string]{}
+	_, err := lazy.Get()
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionPoint)
+}
@@ -0,0 +1,44 @@
+package inject
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestLRUCache_Eviction(t *testing.T) {
+	cache := newLRUCache[string, int](2)
+	cache.put("a", 1)
+	cache.put("b", 2)
+
+	// touching a makes b the least recently used entry
+	value, cached := cache.get("a")
+	assert.True(t, cached)
+	assert.Equal(t, 1, value)
+
+	cache.put("c", 3)
+	assert.Equal(t, 2, cache.len())
+
+	_, cached = cache.get("b")
+	assert.False(t, cached)
+	_, cached = cache.get("a")
+	assert.True(t, cached)
+	_, cached = cache.get("c")
+	assert.True(t, cached)
+}
+
+func TestRegistry_AssignabilityCacheBounded(t *testing.T) {
+	r := NewRegistryWithOptions(WithCacheSize(2))
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	types := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(&NotFoundError{})}
+	for _, actualType := range types {
+		r.isAssignableFrom(errorType, actualType)
+	}
+	assert.Equal(t, 2, r.assignable.len())
+
+	assignable, cached := r.assignable.get(assignability{expectedType: errorType, actualType: types[2]})
+	assert.True(t, cached)
+	assert.True(t, assignable)
+	_, cached = r.assignable.get(assignability{expectedType: errorType, actualType: types[0]})
+	assert.False(t, cached)
+}
@@ -0,0 +1,34 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// Option configures a registry created with NewRegistryWithOptions.
+type Option func(r *Registry)
+
+// WithCacheSize caches the assignability checks of up to size pairs of types, evicting the least recently used.
+// This speeds up resolving interfaces, while bounding the memory of long running services resolving many types.
+// Registries without a cache size don't cache assignability at all.
+func WithCacheSize(size int) Option {
+	return func(r *Registry) {
+		if size > 0 {
+			r.assignable = newLRUCache[assignability, bool](size)
+		}
+	}
+}
+
+// NewRegistryWithOptions creates a registry like NewRegistry, configured by options.
+func NewRegistryWithOptions(options ...Option) *Registry {
+	r := NewRegistry()
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// assignability is the key of the assignability cache.
+type assignability struct {
+	expectedType reflect.Type
+	actualType   reflect.Type
+}
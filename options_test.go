@@ -0,0 +1,28 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestNewRegistryWithOptions_CacheSize(t *testing.T) {
+	registry := inject.NewRegistryWithOptions(inject.WithCacheSize(1))
+	if !assert.NoError(t, registry.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	// resolving repeatedly, while evicting in between, must not change the outcome
+	for i := 0; i < 3; i++ {
+		result, err := registry.GetByName("service", reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.IsType(t, &SimpleTestInterfaceImpl{}, result)
+
+		_, err = registry.GetByName("service", reflect.TypeOf((*io.Closer)(nil)).Elem())
+		assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	}
+}
@@ -0,0 +1,172 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dependency references a binding, either by name or, if the name is empty, by type.
+type dependency struct {
+	name         string
+	expectedType reflect.Type
+	// optional dependencies may be missing, e.g. fields tagged optional.
+	optional bool
+	// lazy dependencies are resolved on first access, not when the dependent is resolved or populated.
+	lazy bool
+}
+
+// dependencies returns the bindings entry depends on, as far as they can be discovered without producing anything.
+// These are the declared dependencies, the annotated fields of bound structs and the parameters of constructors.
+func (r *Registry) dependencies(entry *registryEntry) []dependency {
+	var dependencies []dependency
+	for _, name := range entry.deps {
+		dependencies = append(dependencies, dependency{name: name})
+	}
+
+	if ctor, ok := entry.source.(*constructor); ok {
+		fnType := ctor.fn.Type()
+		for i := 0; i < fnType.NumIn(); i++ {
+			dependencies = append(dependencies, dependency{expectedType: fnType.In(i)})
+		}
+		return dependencies
+	}
+
+	sourceType := reflect.TypeOf(entry.source)
+	if isProducer(entry.source) || sourceType.Kind() != reflect.Ptr || sourceType.Elem().Kind() != reflect.Struct {
+		return dependencies
+	}
+
+	return append(dependencies, r.fieldDependencies(sourceType.Elem())...)
+}
+
+// fieldDependencies returns the bindings injected into the selected fields of structType.
+func (r *Registry) fieldDependencies(structType reflect.Type) []dependency {
+	var dependencies []dependency
+	selector := r.selectorFor(structType)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := selector(field)
+		if !ok {
+			continue
+		}
+		name, options := parseTag(tag)
+		if prefix, ok := strings.CutPrefix(name, "prefix:"); ok {
+			for _, key := range sortedKeys(r.GetByPrefix(prefix)) {
+				dependencies = append(dependencies, dependency{name: prefix + key})
+			}
+			continue
+		}
+		if isLazy(field.Type) {
+			dependencies = append(dependencies, dependency{name: name, expectedType: lazyType(field.Type), lazy: true})
+			continue
+		}
+		dependencies = append(dependencies, dependency{
+			name:         name,
+			expectedType: field.Type,
+			optional:     options.has("optional") || options.has("default"),
+		})
+	}
+	return dependencies
+}
+
+// bindingName returns the name of the binding, which would satisfy dep, and the registry it is bound in.
+// That is r itself or, for child registries, the closest ancestor binding it.
+func (r *Registry) bindingName(dep dependency) (*Registry, string, error) {
+	if dep.name != "" {
+		for owner := r; owner != nil; owner = owner.parent {
+			if _, exists := owner.entry(dep.name); exists {
+				return owner, dep.name, nil
+			}
+		}
+		return nil, "", &NotFoundError{Name: dep.name}
+	}
+
+	owner, name, err := r.bindingNameByType(dep.expectedType)
+	if errors.Is(err, ErrEntryNotFound) && isInterfacePointer(dep.expectedType) {
+		owner, name, err = r.bindingNameByType(dep.expectedType.Elem())
+	}
+	return owner, name, err
+}
+
+// bindingNameByType returns the name of the binding lookupByType would use for expectedType
+// and the registry it is bound in.
+func (r *Registry) bindingNameByType(expectedType reflect.Type) (*Registry, string, error) {
+	name := expectedType.String()
+	if _, exists := r.entry(name); exists {
+		return r, name, nil
+	}
+
+	if expectedType.Kind() == reflect.Interface {
+		name, err := r.implementer(expectedType)
+		if !errors.Is(err, ErrEntryNotFound) {
+			return r, name, err
+		}
+	}
+	if r.parent != nil {
+		return r.parent.bindingNameByType(expectedType)
+	}
+	return nil, "", &NotFoundError{Name: expectedType.String()}
+}
+
+// planned identifies a binding of Plan, child registries may bind the name of a binding of their parent as well.
+type planned struct {
+	registry *Registry
+	name     string
+}
+
+// Plan returns the names of all bindings touched by resolving expectedType, without producing anything.
+// Dependencies are listed before the bindings depending on them, the binding for expectedType comes last.
+// For child registries this includes the bindings resolved from their ancestors.
+func (r *Registry) Plan(expectedType reflect.Type) ([]string, error) {
+	owner, name, err := r.bindingName(dependency{expectedType: expectedType})
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []string
+	done := make(map[planned]bool)
+	var visit func(binding planned, path []planned) error
+	visit = func(binding planned, path []planned) error {
+		for i, visiting := range path {
+			if visiting == binding {
+				var cycle []string
+				for _, step := range append(path[i:], binding) {
+					cycle = append(cycle, step.name)
+				}
+				return fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> "))
+			}
+		}
+		if done[binding] {
+			return nil
+		}
+
+		entry, _ := binding.registry.entry(binding.name)
+		path = append(path, binding)
+		for _, dep := range binding.registry.dependencies(entry) {
+			if dep.lazy {
+				continue
+			}
+			depOwner, depName, err := binding.registry.bindingName(dep)
+			if err != nil && dep.optional {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("resolving dependency of %q: %w", binding.name, err)
+			}
+			if err := visit(planned{registry: depOwner, name: depName}, path); err != nil {
+				return err
+			}
+		}
+
+		done[binding] = true
+		plan = append(plan, binding.name)
+		return nil
+	}
+
+	if err := visit(planned{registry: owner, name: name}, nil); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,107 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type PlanRepository struct {
+	DSN string `inject:"dsn"`
+}
+
+type PlanService struct {
+	Repository *PlanRepository `inject:""`
+	Greeting   string          `inject:"greeting"`
+}
+
+type PlanHandler struct {
+	Service *PlanService `inject:""`
+}
+
+func TestServiceLocator_Plan(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&PlanHandler{})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&PlanService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&PlanRepository{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("dsn", "postgres://localhost")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	plan, err := registry.Plan(reflect.TypeOf(&PlanHandler{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{
+		"dsn",
+		"*inject_test.PlanRepository",
+		"greeting",
+		"*inject_test.PlanService",
+		"*inject_test.PlanHandler",
+	}, plan)
+}
+
+func TestServiceLocator_PlanMissingDependency(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&PlanHandler{})) {
+		return
+	}
+
+	_, err := registry.Plan(reflect.TypeOf(&PlanHandler{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+type PlanCycleA struct {
+	B *PlanCycleB `inject:""`
+}
+
+type PlanCycleB struct {
+	A *PlanCycleA `inject:""`
+}
+
+func TestServiceLocator_PlanCycle(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&PlanCycleA{})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&PlanCycleB{})) {
+		return
+	}
+
+	_, err := registry.Plan(reflect.TypeOf(&PlanCycleA{}))
+	assert.ErrorIs(t, err, inject.ErrCircularDependency)
+	assert.EqualError(t, err, "circular dependency: "+
+		"*inject_test.PlanCycleA -> *inject_test.PlanCycleB -> *inject_test.PlanCycleA")
+}
+
+func TestServiceLocator_PlanConstructor(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&PlanRepository{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("dsn", "postgres://localhost")) {
+		return
+	}
+	err := inject.Provide[*PlanService](registry, func(repository *PlanRepository) *PlanService {
+		return &PlanService{Repository: repository}
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plan, err := registry.Plan(reflect.TypeOf(&PlanService{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"dsn", "*inject_test.PlanRepository", "*inject_test.PlanService"}, plan)
+}
@@ -0,0 +1,41 @@
+package inject
+
+import (
+	"fmt"
+)
+
+// BindPolicy decides what happens, if a binding is bound under a name which is already in use.
+type BindPolicy int
+
+const (
+	// LastWins replaces the existing binding. This is the default.
+	LastWins BindPolicy = iota
+	// FirstWins keeps the existing binding and silently ignores the new one.
+	FirstWins
+	// ErrorOnDuplicate keeps the existing binding and fails with ErrDuplicateBinding.
+	ErrorOnDuplicate
+)
+
+// SetBindPolicy sets the policy applied when binding under a name which is already in use.
+// Priority producers, groups and the temporary bindings of ResolveWith are not affected.
+func (r *Registry) SetBindPolicy(policy BindPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bindPolicy = policy
+}
+
+// admits reports whether a binding may be put under name according to the bind policy.
+// The caller must hold the lock.
+func (r *Registry) admits(name string) (bool, error) {
+	if _, exists := r.entries[name]; !exists {
+		return true, nil
+	}
+	switch r.bindPolicy {
+	case FirstWins:
+		return false, nil
+	case ErrorOnDuplicate:
+		return false, fmt.Errorf("%w: %q", ErrDuplicateBinding, name)
+	default:
+		return true, nil
+	}
+}
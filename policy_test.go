@@ -0,0 +1,60 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_BindPolicyLastWins(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetBindPolicy(inject.LastWins)
+	if !assert.NoError(t, registry.BindWithName("name", "first")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("name", "last")) {
+		return
+	}
+
+	result, err := registry.GetByName("name", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "last", result)
+}
+
+func TestServiceLocator_BindPolicyFirstWins(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetBindPolicy(inject.FirstWins)
+	if !assert.NoError(t, registry.BindWithName("name", "first")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("name", "last")) {
+		return
+	}
+
+	result, err := registry.GetByName("name", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "first", result)
+}
+
+func TestServiceLocator_BindPolicyErrorOnDuplicate(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.SetBindPolicy(inject.ErrorOnDuplicate)
+	if !assert.NoError(t, registry.BindWithName("name", "first")) {
+		return
+	}
+
+	err := registry.BindWithName("name", "last")
+	assert.ErrorIs(t, err, inject.ErrDuplicateBinding)
+	assert.EqualError(t, err, `duplicate binding: "name"`)
+
+	result, err := registry.GetByName("name", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "first", result)
+}
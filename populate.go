@@ -0,0 +1,423 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Populate calls InjectFields for every registered struct and Init() on all registered bindings,
+// implementing the inject.Service interface.
+// Bindings are populated after the bindings they depend on, see BindWithDeps. Afterwards singletons bound
+// with BindEager are produced.
+// Calls while the registry is populated or populating, e.g. from within Init, do nothing.
+// So exactly one population runs, even if Populate is called concurrently. Concurrent calls don't wait for it
+// to finish, use WaitPopulated for that.
+func (r *Registry) Populate() error {
+	_, err := r.PopulateReport()
+	return err
+}
+
+// MustPopulate works like Populate, but panics if populating fails.
+// It is meant for wiring in main, where a failing service is fatal anyway.
+func (r *Registry) MustPopulate() {
+	if err := r.Populate(); err != nil {
+		panic(fmt.Errorf("populating registry: %w", err))
+	}
+}
+
+// Report lists the outcome of populating each service, see PopulateReport.
+type Report struct {
+	Services []ServiceReport
+}
+
+// ServiceReport is the outcome of populating a single binding.
+type ServiceReport struct {
+	Name        string
+	Initialized bool
+	Duration    time.Duration
+	Err         error
+}
+
+// PopulateReport works like Populate, but additionally reports for every populated binding,
+// whether it was initialized, how long it took and the error, if any.
+// Populating stops at the first error, the remaining services are reported as not initialized.
+func (r *Registry) PopulateReport() (report Report, err error) {
+	if !r.beginPopulate() {
+		return Report{}, nil
+	}
+	defer r.endPopulate(&err)
+
+	entries := r.snapshot()
+	var names []string
+	for _, name := range r.initOrder(entries) {
+		if populatable(entries[name]) {
+			names = append(names, name)
+		}
+	}
+
+	report = Report{Services: make([]ServiceReport, len(names))}
+	var firstErr error
+	for i, name := range names {
+		report.Services[i].Name = name
+		if firstErr != nil {
+			continue
+		}
+
+		start := time.Now()
+		err := r.populateEntry(entries[name])
+		report.Services[i].Duration = time.Since(start)
+		report.Services[i].Initialized = err == nil
+		report.Services[i].Err = err
+		firstErr = err
+	}
+	if firstErr == nil {
+		firstErr = r.warmEager()
+	}
+	if firstErr == nil {
+		r.MarkPopulated()
+	}
+	return report, firstErr
+}
+
+// PopulateParallel works like Populate, but initializes independent bindings concurrently,
+// running at most maxConcurrency at once. A binding is only populated after all bindings it depends on.
+// The first error is returned, bindings not yet started are skipped then.
+// Other than Populate, bindings depending on each other in a cycle are rejected with ErrCircularDependency.
+func (r *Registry) PopulateParallel(maxConcurrency int) (err error) {
+	if !r.beginPopulate() {
+		return nil
+	}
+	defer r.endPopulate(&err)
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	entries := r.snapshot()
+	levels, err := r.initLevels(entries)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	failed := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr
+	}
+
+	semaphore := make(chan struct{}, maxConcurrency)
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		for _, name := range level {
+			semaphore <- struct{}{}
+			if failed() != nil {
+				<-semaphore
+				break
+			}
+
+			wg.Add(1)
+			go func(entry *registryEntry) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				if err := r.populateEntry(entry); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}(entries[name])
+		}
+		wg.Wait()
+		if err := failed(); err != nil {
+			return err
+		}
+	}
+	if err := r.warmEager(); err != nil {
+		return err
+	}
+	r.MarkPopulated()
+	return nil
+}
+
+// ServicesToInit returns the sorted names of the bindings implementing Service, whose Init is called by Populate.
+// A service bound under several names is listed once.
+func (r *Registry) ServicesToInit() []string {
+	var names []string
+	for name, entry := range uniqueEntries(r.snapshot()) {
+		if _, ok := entry.source.(Service); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsPopulated reports whether the registry was populated successfully.
+// Populate does nothing while the registry is marked as populated.
+func (r *Registry) IsPopulated() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.populated
+}
+
+// MarkPopulated marks the registry as populated, e.g. after initializing the services manually.
+func (r *Registry) MarkPopulated() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.populated = true
+}
+
+// ResetPopulated clears the populated mark, so the next Populate runs again, e.g. to initialize services bound later.
+// Services populated before are populated again as well.
+func (r *Registry) ResetPopulated() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.populated = false
+}
+
+// population is a running Populate, see WaitPopulated.
+type population struct {
+	done chan struct{}
+	err  error
+}
+
+// WaitPopulated waits for the running population to finish and returns its error, e.g. after a concurrent
+// Populate returned right away. Without a running population it returns nil right away.
+// It must not be called from within the population, e.g. from Init, which would wait for itself.
+func (r *Registry) WaitPopulated() error {
+	r.mu.RLock()
+	running := r.population
+	r.mu.RUnlock()
+	if running == nil {
+		return nil
+	}
+	<-running.done
+	return running.err
+}
+
+// beginPopulate starts a population, which must be ended with endPopulate. It reports false, if the registry
+// is populated already or another Populate is running, e.g. called from within a service's Init.
+// Populate does nothing then.
+func (r *Registry) beginPopulate() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.populated {
+		r.log.Warn("Service locator is already populated")
+		return false
+	}
+	if r.population != nil {
+		r.log.Warn("Service locator is already populating")
+		return false
+	}
+	r.population = &population{done: make(chan struct{})}
+	return true
+}
+
+// endPopulate ends the running population with the error err points to, releasing WaitPopulated.
+// It must be deferred, so WaitPopulated is released by a panicking population as well.
+func (r *Registry) endPopulate(err *error) {
+	result := *err
+	recovered := recover()
+	if recovered != nil {
+		result = fmt.Errorf("%w: %v", ErrRecoveredPanic, recovered)
+	}
+
+	r.mu.Lock()
+	running := r.population
+	r.population = nil
+	r.mu.Unlock()
+
+	running.err = result
+	close(running.done)
+	if recovered != nil {
+		panic(recovered)
+	}
+}
+
+// populatable reports whether populateEntry has anything to do for entry.
+func populatable(entry *registryEntry) bool {
+	if _, ok := entry.source.(Service); ok {
+		return true
+	}
+	sourceType := reflect.TypeOf(entry.source)
+	return sourceType != nil && sourceType.Kind() == reflect.Ptr && sourceType.Elem().Kind() == reflect.Struct
+}
+
+// populateEntry injects the fields of a bound struct and initializes it, if it implements Service.
+func (r *Registry) populateEntry(entry *registryEntry) error {
+	serviceType := reflect.TypeOf(entry.source)
+	if serviceType.Kind() == reflect.Ptr && serviceType.Elem().Kind() == reflect.Struct {
+		if err := r.InjectFields(entry.source); err != nil {
+			return err
+		}
+	}
+
+	service, ok := entry.source.(Service)
+	if ok {
+		if err := r.protect(func() error { return service.Init(r) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BindWithDeps binds value under name like BindWithName and declares the bindings it depends on.
+// Populate initializes the dependencies first, even if they aren't discoverable from annotated fields.
+func (r *Registry) BindWithDeps(name string, value interface{}, deps ...string) error {
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    value,
+		deps:      deps,
+	})
+}
+
+// BindWithOrder binds value under name like BindWithName and initializes it by ascending order on Populate.
+// Bindings without an explicit order have order 0, bindings of the same order are initialized in the order they were bound.
+// Dependencies are still initialized before their dependents, regardless of the order.
+func (r *Registry) BindWithOrder(name string, value interface{}, order int) error {
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    value,
+		order:     order,
+	})
+}
+
+// canonicalNames maps the name of each entry to the name of the entry representing its source.
+// Entries sharing their source, e.g. values bound under several types, are represented by the lowest name.
+func canonicalNames(entries map[string]*registryEntry) map[string]string {
+	names := sortedKeys(entries)
+
+	canonical := make(map[string]string, len(entries))
+	representatives := make(map[interface{}]string)
+	for _, name := range names {
+		canonical[name] = name
+		source := entries[name].source
+		if source == nil || !reflect.ValueOf(source).Comparable() {
+			// e.g. a struct holding a slice in an interface field
+			continue
+		}
+		if representative, seen := representatives[source]; seen {
+			canonical[name] = representative
+		} else {
+			representatives[source] = name
+		}
+	}
+	return canonical
+}
+
+// uniqueEntries drops entries sharing their source with another entry, so each source gets populated only once.
+func uniqueEntries(entries map[string]*registryEntry) map[string]*registryEntry {
+	unique := make(map[string]*registryEntry, len(entries))
+	for name, canonical := range canonicalNames(entries) {
+		if name == canonical {
+			unique[name] = entries[name]
+		}
+	}
+	return unique
+}
+
+// dependencyGraph returns for each of the unique entries the names of the unique entries it depends on.
+// Dependencies which can't be resolved are ignored here, they fail once the entry gets populated.
+func (r *Registry) dependencyGraph(entries map[string]*registryEntry) map[string]map[string]bool {
+	canonical := canonicalNames(entries)
+	graph := make(map[string]map[string]bool, len(entries))
+	for name, entry := range entries {
+		if canonical[name] != name {
+			continue
+		}
+
+		dependencies := make(map[string]bool)
+		for _, dep := range r.dependencies(entry) {
+			if dep.lazy {
+				continue
+			}
+			owner, depName, err := r.bindingName(dep)
+			if err != nil || owner != r {
+				continue
+			}
+			if depName, exists := canonical[depName]; exists && depName != name {
+				dependencies[depName] = true
+			}
+		}
+		graph[name] = dependencies
+	}
+	return graph
+}
+
+// initOrder orders the names of the unique entries, so that dependencies come before their dependents.
+// Otherwise names are ordered by initsBefore, cycles are broken up at the first name.
+func (r *Registry) initOrder(entries map[string]*registryEntry) []string {
+	pending := r.dependencyGraph(entries)
+	order := make([]string, 0, len(pending))
+	for len(pending) > 0 {
+		next, cycle := "", ""
+		for name, dependencies := range pending {
+			if len(dependencies) == 0 && (next == "" || initsBefore(entries, name, next)) {
+				next = name
+			}
+			if cycle == "" || initsBefore(entries, name, cycle) {
+				cycle = name
+			}
+		}
+		if next == "" {
+			next = cycle
+		}
+
+		order = append(order, next)
+		delete(pending, next)
+		for _, dependencies := range pending {
+			delete(dependencies, next)
+		}
+	}
+	return order
+}
+
+// initsBefore reports whether the entry named a is initialized before b, unless dependencies require otherwise.
+// Entries are ordered by their order, which is 0 unless bound with BindWithOrder, and then by insertion.
+func initsBefore(entries map[string]*registryEntry, a, b string) bool {
+	entryA, entryB := entries[a], entries[b]
+	if entryA.order != entryB.order {
+		return entryA.order < entryB.order
+	}
+	return entryA.id < entryB.id
+}
+
+// initLevels groups the names of the unique entries into levels, where each level only depends on the levels before.
+func (r *Registry) initLevels(entries map[string]*registryEntry) ([][]string, error) {
+	pending := r.dependencyGraph(entries)
+	var levels [][]string
+	for len(pending) > 0 {
+		var level []string
+		for name, dependencies := range pending {
+			if len(dependencies) == 0 {
+				level = append(level, name)
+			}
+		}
+		if len(level) == 0 {
+			var names []string
+			for name := range pending {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("%w: between %s", ErrCircularDependency, strings.Join(names, ", "))
+		}
+
+		sort.Slice(level, func(i, j int) bool {
+			return initsBefore(entries, level[i], level[j])
+		})
+		for _, name := range level {
+			delete(pending, name)
+			for _, dependencies := range pending {
+				delete(dependencies, name)
+			}
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
@@ -0,0 +1,466 @@
+package inject_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type BarrierService struct {
+	barrier *sync.WaitGroup
+}
+
+func (b *BarrierService) Init(registry *inject.Registry) error {
+	b.barrier.Done()
+	done := make(chan struct{})
+	go func() {
+		b.barrier.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return errors.New("services were not initialized concurrently")
+	}
+}
+
+func TestServiceLocator_PopulateParallel(t *testing.T) {
+	registry := inject.NewRegistry()
+	barrier := &sync.WaitGroup{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		barrier.Add(1)
+		if !assert.NoError(t, registry.BindWithName(name, &BarrierService{barrier: barrier})) {
+			return
+		}
+	}
+
+	assert.NoError(t, registry.PopulateParallel(4))
+}
+
+type OrderedDependency struct {
+	initialized *[]string
+}
+
+func (o *OrderedDependency) Init(registry *inject.Registry) error {
+	*o.initialized = append(*o.initialized, "dependency")
+	return nil
+}
+
+type OrderedDependent struct {
+	Dependency  *OrderedDependency `inject:""`
+	initialized *[]string
+}
+
+func (o *OrderedDependent) Init(registry *inject.Registry) error {
+	*o.initialized = append(*o.initialized, "dependent")
+	return nil
+}
+
+func TestServiceLocator_PopulateParallelOrder(t *testing.T) {
+	var initialized []string
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&OrderedDependent{initialized: &initialized})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&OrderedDependency{initialized: &initialized})) {
+		return
+	}
+
+	if !assert.NoError(t, registry.PopulateParallel(2)) {
+		return
+	}
+	assert.Equal(t, []string{"dependency", "dependent"}, initialized)
+}
+
+type FailingService struct {
+}
+
+func (f *FailingService) Init(registry *inject.Registry) error {
+	return errors.New("init failed")
+}
+
+func TestServiceLocator_PopulateParallelError(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&FailingService{})) {
+		return
+	}
+
+	assert.EqualError(t, registry.PopulateParallel(2), "init failed")
+}
+
+type CountingService struct {
+	initialized int
+}
+
+func (c *CountingService) Init(registry *inject.Registry) error {
+	c.initialized++
+	return nil
+}
+
+func (c *CountingService) Test() string {
+	return "counting"
+}
+
+func TestServiceLocator_PopulateSharedSource(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &CountingService{}
+	if !assert.NoError(t, registry.BindImplemented(service, reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, 1, service.initialized)
+}
+
+type ValueHolder struct {
+	V interface{}
+}
+
+func TestServiceLocator_PopulateUncomparableSource(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("a", ValueHolder{V: []int{1}})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("b", ValueHolder{V: []int{1}})) {
+		return
+	}
+
+	assert.NoError(t, registry.Populate())
+}
+
+func TestServiceLocator_PopulateReport(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("a", &CountingService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("b", &CountingService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	report, err := registry.PopulateReport()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, report.Services, 2) {
+		assert.Equal(t, "a", report.Services[0].Name)
+		assert.True(t, report.Services[0].Initialized)
+		assert.NoError(t, report.Services[0].Err)
+		assert.Equal(t, "b", report.Services[1].Name)
+		assert.True(t, report.Services[1].Initialized)
+		assert.NoError(t, report.Services[1].Err)
+	}
+}
+
+func TestServiceLocator_PopulateReportError(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("a", &FailingService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("b", &CountingService{})) {
+		return
+	}
+
+	report, err := registry.PopulateReport()
+	assert.EqualError(t, err, "init failed")
+
+	if assert.Len(t, report.Services, 2) {
+		assert.Equal(t, "a", report.Services[0].Name)
+		assert.False(t, report.Services[0].Initialized)
+		assert.EqualError(t, report.Services[0].Err, "init failed")
+		assert.Equal(t, "b", report.Services[1].Name)
+		assert.False(t, report.Services[1].Initialized)
+		assert.NoError(t, report.Services[1].Err)
+	}
+}
+
+func TestServiceLocator_PopulatedFlag(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &CountingService{}
+	if !assert.NoError(t, registry.Bind(service)) {
+		return
+	}
+	assert.False(t, registry.IsPopulated())
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.True(t, registry.IsPopulated())
+	assert.Equal(t, 1, service.initialized)
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, 1, service.initialized)
+
+	registry.ResetPopulated()
+	assert.False(t, registry.IsPopulated())
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, 2, service.initialized)
+
+	registry.ResetPopulated()
+	registry.MarkPopulated()
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, 2, service.initialized)
+}
+
+func TestServiceLocator_ServicesToInit(t *testing.T) {
+	type PlainStruct struct {
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("counting", &CountingService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("failing", &FailingService{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("plain", &PlainStruct{})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	assert.Equal(t, []string{"counting", "failing"}, registry.ServicesToInit())
+}
+
+type RecordingService struct {
+	name        string
+	initialized *[]string
+}
+
+func (r *RecordingService) Init(registry *inject.Registry) error {
+	*r.initialized = append(*r.initialized, r.name)
+	return nil
+}
+
+func TestServiceLocator_BindWithDeps(t *testing.T) {
+	var initialized []string
+	registry := inject.NewRegistry()
+	err := registry.BindWithDeps("api", &RecordingService{name: "api", initialized: &initialized}, "database", "cache")
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = registry.BindWithDeps("cache", &RecordingService{name: "cache", initialized: &initialized}, "database")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("database", &RecordingService{name: "database", initialized: &initialized})) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, []string{"database", "cache", "api"}, initialized)
+}
+
+type ReentrantService struct {
+	initialized int
+	err         error
+}
+
+func (s *ReentrantService) Init(registry *inject.Registry) error {
+	s.initialized++
+	s.err = registry.Populate()
+	return nil
+}
+
+func TestServiceLocator_PopulateReentrant(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &ReentrantService{}
+	registry.MustBind(service)
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.NoError(t, service.err)
+	assert.Equal(t, 1, service.initialized)
+	assert.True(t, registry.IsPopulated())
+}
+
+type ConcurrentlyPopulatedService struct {
+	initialized atomic.Int32
+}
+
+func (s *ConcurrentlyPopulatedService) Init(registry *inject.Registry) error {
+	s.initialized.Add(1)
+	// keep the population running, while the other goroutines call Populate
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestServiceLocator_PopulateConcurrently(t *testing.T) {
+	registry := inject.NewRegistry()
+	services := make([]*ConcurrentlyPopulatedService, 5)
+	for i := range services {
+		services[i] = &ConcurrentlyPopulatedService{}
+		if !assert.NoError(t, registry.BindWithName(fmt.Sprintf("service%d", i), services[i])) {
+			return
+		}
+	}
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			assert.NoError(t, registry.Populate())
+			assert.NoError(t, registry.WaitPopulated())
+			assert.True(t, registry.IsPopulated())
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	for _, service := range services {
+		assert.Equal(t, int32(1), service.initialized.Load())
+	}
+}
+
+type BlockingService struct {
+	started chan struct{}
+	release chan error
+}
+
+func (s *BlockingService) Init(registry *inject.Registry) error {
+	close(s.started)
+	return <-s.release
+}
+
+func TestServiceLocator_WaitPopulated(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &BlockingService{started: make(chan struct{}), release: make(chan error)}
+	if !assert.NoError(t, registry.Bind(service)) {
+		return
+	}
+	assert.NoError(t, registry.WaitPopulated())
+
+	go func() {
+		_ = registry.Populate()
+	}()
+	<-service.started
+
+	// a concurrent call returns right away, WaitPopulated waits for the running population
+	assert.NoError(t, registry.Populate())
+	assert.False(t, registry.IsPopulated())
+	errInit := errors.New("init failed")
+	go func() {
+		service.release <- errInit
+	}()
+	assert.ErrorIs(t, registry.WaitPopulated(), errInit)
+	assert.False(t, registry.IsPopulated())
+}
+
+type SpawningService struct {
+	err error
+}
+
+func (s *SpawningService) Init(registry *inject.Registry) error {
+	done := make(chan error)
+	go func() {
+		done <- registry.Populate()
+	}()
+	s.err = <-done
+	return nil
+}
+
+func TestServiceLocator_PopulateFromGoroutineOfInit(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &SpawningService{}
+	registry.MustBind(service)
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.NoError(t, service.err)
+	assert.True(t, registry.IsPopulated())
+}
+
+func TestServiceLocator_PopulateParallelReentrant(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &ReentrantService{}
+	registry.MustBind(service)
+
+	if !assert.NoError(t, registry.PopulateParallel(2)) {
+		return
+	}
+	assert.NoError(t, service.err)
+	assert.Equal(t, 1, service.initialized)
+	assert.True(t, registry.IsPopulated())
+}
+
+func TestServiceLocator_MustPopulate(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&FailingService{})) {
+		return
+	}
+
+	assert.PanicsWithError(t, "populating registry: init failed", registry.MustPopulate)
+}
+
+func TestServiceLocator_BindWithOrder(t *testing.T) {
+	var initialized []string
+	registry := inject.NewRegistry()
+	for _, binding := range []struct {
+		name  string
+		order int
+	}{{"c", 2}, {"b", 1}, {"z", 1}, {"a", 3}, {"y", 1}} {
+		err := registry.BindWithOrder(binding.name, &RecordingService{name: binding.name, initialized: &initialized}, binding.order)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	if !assert.NoError(t, registry.BindWithName("unordered", &RecordingService{name: "unordered", initialized: &initialized})) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, []string{"unordered", "b", "z", "y", "c", "a"}, initialized)
+}
+
+func TestServiceLocator_BindWithOrderMixed(t *testing.T) {
+	for run := 0; run < 50; run++ {
+		var initialized []string
+		registry := inject.NewRegistry()
+		if !assert.NoError(t, registry.BindWithOrder("c", &RecordingService{name: "c", initialized: &initialized}, 0)) {
+			return
+		}
+		if !assert.NoError(t, registry.BindWithOrder("a", &RecordingService{name: "a", initialized: &initialized}, 0)) {
+			return
+		}
+		if !assert.NoError(t, registry.BindWithName("b", &RecordingService{name: "b", initialized: &initialized})) {
+			return
+		}
+
+		// ordered and unordered bindings of the same order are initialized in insertion order
+		if !assert.NoError(t, registry.Populate()) {
+			return
+		}
+		if !assert.Equal(t, []string{"c", "a", "b"}, initialized) {
+			return
+		}
+	}
+}
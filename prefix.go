@@ -0,0 +1,46 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetByPrefix returns all values bound under a name starting with prefix, keyed by their name without the prefix.
+// Producers are returned as bound, they are not invoked.
+func (r *Registry) GetByPrefix(prefix string) map[string]interface{} {
+	values := make(map[string]interface{})
+	for name, entry := range r.snapshot() {
+		if key, ok := strings.CutPrefix(name, prefix); ok {
+			values[key] = entry.source
+		}
+	}
+	return values
+}
+
+// resolvePrefix resolves all bindings with a name starting with prefix into a map of mapType,
+// keyed by their name without the prefix. mapType must be a map with string keys.
+func (r *Registry) resolvePrefix(mapType reflect.Type, prefix string, res resolution) (interface{}, error) {
+	if mapType.Kind() != reflect.Map || mapType.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("%w: prefix %q requires a map with string keys, got %s",
+			ErrInvalidInjectionType, prefix, mapType)
+	}
+
+	values := reflect.MakeMap(mapType)
+	for name := range r.snapshot() {
+		key, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		value, err := r.lookup(name, mapType.Elem(), res)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil || !reflect.TypeOf(value).AssignableTo(mapType.Elem()) {
+			return nil, fmt.Errorf("%w: %q is %T, not assignable to %s",
+				ErrInvalidInjectionType, name, value, mapType.Elem())
+		}
+		values.SetMapIndex(reflect.ValueOf(key).Convert(mapType.Key()), reflect.ValueOf(value))
+	}
+	return values.Interface(), nil
+}
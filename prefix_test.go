@@ -0,0 +1,87 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_GetByPrefix(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, registry.BindWithName("handlers.users", "users")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("handlers.orders", "orders")) {
+		return
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"users":  "users",
+		"orders": "orders",
+	}, registry.GetByPrefix("handlers."))
+}
+
+func TestServiceLocator_InjectFieldsPrefix(t *testing.T) {
+	type InjectInto struct {
+		Handlers map[string]SimpleTestInterface `inject:"prefix:handlers."`
+	}
+
+	first := &SimpleTestInterfaceImpl{}
+	second := &OtherTestInterfaceImpl{}
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("handlers.first", first)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("handlers.second", second)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("other", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	assert.Equal(t, map[string]SimpleTestInterface{
+		"first":  first,
+		"second": second,
+	}, injectInto.Handlers)
+}
+
+func TestServiceLocator_InjectFieldsPrefixWrongType(t *testing.T) {
+	type InjectInto struct {
+		Handlers map[string]SimpleTestInterface `inject:"prefix:handlers."`
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("handlers.name", "not a handler")) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	assert.ErrorIs(t, registry.InjectFields(&injectInto), inject.ErrInvalidInjectionType)
+}
+
+func TestPlanPrefix(t *testing.T) {
+	type Router struct {
+		Handlers map[string]string `inject:"prefix:handlers."`
+	}
+
+	registry := inject.NewRegistry()
+	registry.MustBind(&Router{})
+	if !assert.NoError(t, registry.BindWithName("handlers.b", "b")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("handlers.a", "a")) {
+		return
+	}
+
+	plan, err := registry.Plan(reflect.TypeOf(&Router{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"handlers.a", "handlers.b", "*inject_test.Router"}, plan)
+}
@@ -0,0 +1,201 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+func isProducer(source interface{}) bool {
+	_, ok := source.(Producer)
+	return ok
+}
+
+// RegistryProducer is implemented by producers, which need the registry resolving them,
+// e.g. to bind a health check along with the produced database pool.
+// Producers are never invoked while the registry is locked, so they may bind and resolve freely.
+// Bindings made during production are visible to every resolution starting afterwards.
+type RegistryProducer interface {
+	Producer
+	ProduceWith(r *Registry, source interface{}, expectedType reflect.Type) (interface{}, error)
+}
+
+// RegistryProducerFunc is a function implementing RegistryProducer.
+type RegistryProducerFunc func(r *Registry, source interface{}, target reflect.Type) (interface{}, error)
+
+// Produce fails with ErrInvalidProducer, the function can only be invoked by a registry resolving it.
+func (p RegistryProducerFunc) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return nil, fmt.Errorf("%w: registry producer invoked without registry", ErrInvalidProducer)
+}
+
+func (p RegistryProducerFunc) ProduceWith(r *Registry, source interface{}, target reflect.Type) (interface{}, error) {
+	return p(r, source, target)
+}
+
+// SourceType returns the type of the source passed to a producer, with pointers dereferenced.
+// For field injection this is the type of the consuming struct, so producers can tailor values per consumer,
+// e.g. a logger named after SourceType(source).Name(). It returns nil if there is no source.
+func SourceType(source interface{}) reflect.Type {
+	sourceType := reflect.TypeOf(source)
+	for sourceType != nil && sourceType.Kind() == reflect.Ptr {
+		sourceType = sourceType.Elem()
+	}
+	return sourceType
+}
+
+type prioritizedProducer struct {
+	producer Producer
+	priority int
+}
+
+// producerChain tries its producers in descending priority, until one of them doesn't skip.
+type producerChain []prioritizedProducer
+
+func (c producerChain) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return c.ProduceContext(context.Background(), source, target)
+}
+
+func (c producerChain) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	for _, p := range c {
+		value, err := produceContext(ctx, p.producer, source, target)
+		if errors.Is(err, ErrProducerSkip) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrProducerSkip
+}
+
+// BindProducerWithPriority adds a producer for expectedType.
+// On resolution the producer with the highest priority, which doesn't return ErrProducerSkip, wins.
+// Producers of the same priority are tried in the order they were bound.
+// A binding for expectedType made otherwise, e.g. with Bind, is kept and tried after all producers.
+func (r *Registry) BindProducerWithPriority(expectedType reflect.Type, producer Producer, priority int) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+
+	name := expectedType.String()
+	r.mu.Lock()
+	var chain producerChain
+	if entry, exists := r.entries[name]; exists {
+		if existing, ok := entry.source.(producerChain); ok {
+			chain = append(chain, existing...)
+		} else {
+			// keep the existing binding as fallback of the chain
+			chain = append(chain, prioritizedProducer{producer: replacedEntry{registry: r, name: name, entry: entry}, priority: math.MinInt})
+		}
+	}
+	chain = append(chain, prioritizedProducer{producer: producer, priority: priority})
+	sort.SliceStable(chain, func(i, j int) bool {
+		return chain[i].priority > chain[j].priority
+	})
+
+	bound := r.putBound(name, &registryEntry{
+		populated: false,
+		source:    chain,
+	})
+	r.mu.Unlock()
+	return r.injectBound([]boundEntry{bound}, producer)
+}
+
+// replacedEntry resolves an entry taken over by a producer chain as it would have been resolved before,
+// e.g. from the cache of a singleton.
+type replacedEntry struct {
+	registry *Registry
+	name     string
+	entry    *registryEntry
+}
+
+func (p replacedEntry) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return p.ProduceContext(context.Background(), source, target)
+}
+
+func (p replacedEntry) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	res, err := resolution{
+		source:       source,
+		ctx:          ctx,
+		trace:        traceFrom(ctx),
+		constructing: constructingFrom(ctx),
+	}.construct(p.entry, p.name)
+	if err != nil {
+		return nil, err
+	}
+	return p.registry.value(p.entry, target, res)
+}
+
+// typedProducer validates the values of its producer against the type declared at bind time.
+type typedProducer struct {
+	producer     Producer
+	producedType reflect.Type
+}
+
+func (p typedProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return p.ProduceContext(context.Background(), source, target)
+}
+
+func (p typedProducer) ProduceContext(ctx context.Context, source interface{}, target reflect.Type) (interface{}, error) {
+	value, err := produceContext(ctx, p.producer, source, target)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil || !reflect.TypeOf(value).AssignableTo(p.producedType) {
+		return nil, fmt.Errorf("%w: produced %T for %s", ErrInvalidProducer, value, p.producedType)
+	}
+	return value, nil
+}
+
+// NewTypedProducer declares producer to produce values of producedType.
+// Binding the result for a type producedType isn't assignable to fails right away.
+// Every produced value is checked against producedType, so a misbehaving producer fails with ErrInvalidProducer
+// instead of handing out a value of the wrong type.
+func NewTypedProducer(producedType reflect.Type, producer Producer) Producer {
+	return typedProducer{producer: producer, producedType: producedType}
+}
+
+// BindTypedProducer binds a producer declared to produce values of producedType, see NewTypedProducer.
+func (r *Registry) BindTypedProducer(producedType reflect.Type, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.BindWithType(producedType, NewTypedProducer(producedType, producer))
+}
+
+// BindProducerForTypes binds producer for each of types, so a single generic factory serves several requested types.
+// The producer receives the requested type as target, telling it what to build.
+// Nothing is bound, if one of the types can't be bound.
+func (r *Registry) BindProducerForTypes(producer Producer, types ...reflect.Type) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	for _, producedType := range types {
+		if !r.isBindableAs(producedType, producer) {
+			return &TypeMismatchError{Expected: producedType, Actual: reflect.TypeOf(producer)}
+		}
+	}
+
+	r.mu.Lock()
+	admitted := make([]bool, len(types))
+	for i, producedType := range types {
+		var err error
+		if admitted[i], err = r.admits(producedType.String()); err != nil {
+			r.mu.Unlock()
+			return err
+		}
+	}
+	var bound []boundEntry
+	for i, producedType := range types {
+		if admitted[i] {
+			bound = append(bound, r.putBound(producedType.String(), &registryEntry{
+				populated:    false,
+				source:       producer,
+				declaredType: producedType,
+			}))
+		}
+	}
+	r.mu.Unlock()
+	return r.injectBound(bound, producer)
+}
@@ -0,0 +1,328 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func constantProducer(value interface{}) inject.Producer {
+	return inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return value, nil
+	})
+}
+
+func TestServiceLocator_BindProducerWithPriority(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindProducerWithPriority(reflect.TypeOf(""), constantProducer("default"), 0)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindProducerWithPriority(reflect.TypeOf(""), constantProducer("override"), 10)) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "override", result)
+}
+
+func TestServiceLocator_BindProducerWithPrioritySkip(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindProducerWithPriority(reflect.TypeOf(""), constantProducer("default"), 0)) {
+		return
+	}
+	skipping := inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return nil, inject.ErrProducerSkip
+	})
+	if !assert.NoError(t, registry.BindProducerWithPriority(reflect.TypeOf(""), skipping, 10)) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "default", result)
+}
+
+func TestServiceLocator_ProducerSkipNotFound(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return nil, inject.ErrProducerSkip
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByType(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_GetFromSource(t *testing.T) {
+	type Consumer struct {
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return "Hello " + reflect.TypeOf(source).Elem().Name(), nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := registry.GetByTypeFrom(&Consumer{}, reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello Consumer", result)
+
+	result, err = registry.GetByNameFrom(&Consumer{}, "string", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello Consumer", result)
+}
+
+func TestServiceLocator_BindTypedProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindTypedProducer(reflect.TypeOf(""), constantProducer("value"))) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "value", result)
+}
+
+func TestServiceLocator_BindTypedProducerWrongType(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindTypedProducer(reflect.TypeOf(""), constantProducer(42))) {
+		return
+	}
+
+	_, err := registry.GetByType(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrInvalidProducer)
+	assert.EqualError(t, err, "invalid producer: produced int for string")
+}
+
+func TestServiceLocator_BindWithTypeIncompatibleProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	producer := inject.NewTypedProducer(reflect.TypeOf(0), constantProducer(42))
+
+	assert.ErrorIs(t, registry.BindWithType(reflect.TypeOf(""), producer), inject.ErrInvalidInjectionType)
+	assert.NoError(t, registry.BindWithType(reflect.TypeOf(0), producer))
+}
+
+func TestServiceLocator_BindWithTypeIncompatibleProducerFunc(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithType(reflect.TypeOf(""), constantProducer(42))) {
+		return
+	}
+
+	_, err := registry.GetByType(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrInvalidProducer)
+	assert.EqualError(t, err, "invalid producer: produced int for string")
+
+	// the value doesn't leak out as something else than the declared type either
+	_, err = registry.GetByName("string", reflect.TypeOf((*interface{})(nil)).Elem())
+	assert.ErrorIs(t, err, inject.ErrInvalidProducer)
+}
+
+func TestServiceLocator_GetByNameProducedIncompatibleProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("producer", constantProducer(constantProducer("value")))) {
+		return
+	}
+
+	_, err := registry.GetByName("producer", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+}
+
+func TestSourceType(t *testing.T) {
+	type Consumer struct {
+	}
+
+	assert.Equal(t, reflect.TypeOf(Consumer{}), inject.SourceType(&Consumer{}))
+	assert.Equal(t, reflect.TypeOf(Consumer{}), inject.SourceType(Consumer{}))
+	assert.Nil(t, inject.SourceType(nil))
+}
+
+func TestServiceLocator_InjectFieldsByConsumer(t *testing.T) {
+	type Logger struct {
+		name string
+	}
+	type Consumer struct {
+		Log *Logger `inject:",byconsumer"`
+	}
+	type Special struct {
+		Log *Logger `inject:",byconsumer"`
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf(&Logger{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return &Logger{name: inject.SourceType(source).Name()}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("inject_test.Special", &Logger{name: "special"})) {
+		return
+	}
+
+	consumer := Consumer{}
+	if !assert.NoError(t, registry.InjectFields(&consumer)) {
+		return
+	}
+	special := Special{}
+	if !assert.NoError(t, registry.InjectFields(&special)) {
+		return
+	}
+
+	assert.Equal(t, "Consumer", consumer.Log.name)
+	assert.Equal(t, "special", special.Log.name)
+}
+
+func TestServiceLocator_RegistryProducerFunc(t *testing.T) {
+	type Pool struct {
+		healthy bool
+	}
+	type HealthCheck struct {
+		pool *Pool
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindSingleton(reflect.TypeOf(&Pool{}), inject.RegistryProducerFunc(func(r *inject.Registry, source interface{}, target reflect.Type) (interface{}, error) {
+		pool := &Pool{healthy: true}
+		return pool, r.Bind(&HealthCheck{pool: pool})
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByType(reflect.TypeOf(&HealthCheck{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	pool, err := registry.GetByType(reflect.TypeOf(&Pool{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	check, err := registry.GetByType(reflect.TypeOf(&HealthCheck{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, pool, check.(*HealthCheck).pool)
+}
+
+func TestServiceLocator_RegistryProducerFuncWithPriority(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindProducerWithPriority(reflect.TypeOf(""), inject.RegistryProducerFunc(func(r *inject.Registry, source interface{}, target reflect.Type) (interface{}, error) {
+		return inject.GetNamed[string](r, "greeting")
+	}), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result)
+}
+
+func TestServiceLocator_BindProducerForTypes(t *testing.T) {
+	registry := inject.NewRegistry()
+	var targets []reflect.Type
+	producer := inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		targets = append(targets, target)
+		return reflect.New(target.Elem()).Interface(), nil
+	})
+	serviceType := reflect.TypeOf(&SimpleTestInterfaceImpl{})
+	countingType := reflect.TypeOf(&CountingService{})
+	if !assert.NoError(t, registry.BindProducerForTypes(producer, serviceType, countingType)) {
+		return
+	}
+
+	service, err := registry.GetByType(serviceType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &SimpleTestInterfaceImpl{}, service)
+
+	counting, err := registry.GetByType(countingType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.IsType(t, &CountingService{}, counting)
+	assert.Equal(t, []reflect.Type{serviceType, countingType}, targets)
+}
+
+func TestServiceLocator_BindProducerForTypesPartialFailure(t *testing.T) {
+	serviceType := reflect.TypeOf(&SimpleTestInterfaceImpl{})
+	countingType := reflect.TypeOf(&CountingService{})
+
+	registry := inject.NewRegistry()
+	producer := inject.NewTypedProducer(serviceType, constantProducer(&SimpleTestInterfaceImpl{}))
+	err := registry.BindProducerForTypes(producer, serviceType, countingType)
+	var mismatch *inject.TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+	_, err = registry.GetByType(serviceType)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	registry = inject.NewRegistry()
+	registry.SetBindPolicy(inject.ErrorOnDuplicate)
+	counting := &CountingService{}
+	if !assert.NoError(t, registry.Bind(counting)) {
+		return
+	}
+	err = registry.BindProducerForTypes(constantProducer(&SimpleTestInterfaceImpl{}), serviceType, countingType)
+	assert.ErrorIs(t, err, inject.ErrDuplicateBinding)
+	_, err = registry.GetByType(serviceType)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	bound, err := registry.GetByType(countingType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, counting, bound)
+}
+
+func TestServiceLocator_ProducerReturnedNil(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return nil, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	assert.ErrorIs(t, err, inject.ErrProducerReturnedNil)
+	assert.EqualError(t, err, "producer returned nil: inject.ProducerFunc for inject_test.SimpleTestInterface")
+}
+
+func TestServiceLocator_BindProducerWithPriorityKeepsBinding(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &SimpleTestInterfaceImpl{}
+	if !assert.NoError(t, registry.Bind(service)) {
+		return
+	}
+	skipping := inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return nil, inject.ErrProducerSkip
+	})
+	if !assert.NoError(t, registry.BindProducerWithPriority(reflect.TypeOf(service), skipping, 10)) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(service))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, service, result)
+}
@@ -0,0 +1,27 @@
+package inject
+
+// Promise resolves a binding on demand, when Get is called, instead of at injection time.
+// This allows injecting bindings, which are bound only after the injection, e.g. to break init order cycles.
+type Promise[T any] struct {
+	registry *Registry
+	name     string
+}
+
+// BindPromise creates a promise for the binding named name and binds it under "promise:" followed by name,
+// so it can be injected into fields tagged with that name, like `inject:"promise:users"`.
+func BindPromise[T any](r *Registry, name string) (*Promise[T], error) {
+	promise := &Promise[T]{registry: r, name: name}
+	if err := r.BindWithName(promiseName(name), promise); err != nil {
+		return nil, err
+	}
+	return promise, nil
+}
+
+// Get resolves the promised binding. It fails as long as nothing is bound under the promised name.
+func (p *Promise[T]) Get() (T, error) {
+	return GetNamed[T](p.registry, p.name)
+}
+
+func promiseName(name string) string {
+	return "promise:" + name
+}
@@ -0,0 +1,80 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type PromisedService struct {
+	name string
+}
+
+func TestBindPromise(t *testing.T) {
+	type InjectInto struct {
+		Service *inject.Promise[*PromisedService] `inject:"promise:service"`
+	}
+
+	registry := inject.NewRegistry()
+	if _, err := inject.BindPromise[*PromisedService](registry, "service"); !assert.NoError(t, err) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	if !assert.NotNil(t, injectInto.Service) {
+		return
+	}
+
+	_, err := injectInto.Service.Get()
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	if !assert.NoError(t, registry.BindWithName("service", &PromisedService{name: "late"})) {
+		return
+	}
+
+	service, err := injectInto.Service.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "late", service.name)
+}
+
+func TestBindPromise_SameTypeDifferentNames(t *testing.T) {
+	type InjectInto struct {
+		Primary   *inject.Promise[*PromisedService] `inject:"promise:primary"`
+		Secondary *inject.Promise[*PromisedService] `inject:"promise:secondary"`
+	}
+
+	registry := inject.NewRegistry()
+	registry.SetBindPolicy(inject.ErrorOnDuplicate)
+	if _, err := inject.BindPromise[*PromisedService](registry, "primary"); !assert.NoError(t, err) {
+		return
+	}
+	if _, err := inject.BindPromise[*PromisedService](registry, "secondary"); !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("primary", &PromisedService{name: "primary"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("secondary", &PromisedService{name: "secondary"})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	primary, err := injectInto.Primary.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	secondary, err := injectInto.Secondary.Get()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "primary", primary.name)
+	assert.Equal(t, "secondary", secondary.name)
+}
@@ -0,0 +1,55 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// RecoverPanics configures the registry to convert panics of producers, Init and InjectFields into errors,
+// wrapping ErrRecoveredPanic and carrying the stack trace. By default panics are not recovered.
+func (r *Registry) RecoverPanics(recoverPanics bool) {
+	r.recoverPanics = recoverPanics
+}
+
+// protect runs fn, converting a panic into an error if the registry recovers panics.
+func (r *Registry) protect(fn func() error) (err error) {
+	if !r.recoverPanics {
+		return fn()
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v\n%s", ErrRecoveredPanic, recovered, debug.Stack())
+		}
+	}()
+	return fn()
+}
+
+func (r *Registry) invokeProducer(producer Producer, res resolution, expectedType reflect.Type) (interface{}, error) {
+	var value interface{}
+	err := r.protect(func() error {
+		var err error
+		ctx := res.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx = context.WithValue(ctx, registryKey{}, r)
+		if res.trace != nil {
+			ctx = context.WithValue(ctx, traceKey{}, res.trace)
+		}
+		if res.constructing != nil {
+			ctx = context.WithValue(ctx, constructingKey{}, res.constructing)
+		}
+		if res.scope != nil {
+			ctx = context.WithValue(ctx, scopeKey{}, res.scope)
+		}
+		value, err = produceContext(ctx, producer, res.source, expectedType)
+		return err
+	})
+	if err == nil && value == nil {
+		return nil, fmt.Errorf("%w: %T for %s", ErrProducerReturnedNil, producer, expectedType)
+	}
+	return value, err
+}
@@ -0,0 +1,54 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_RecoverPanicsProducer(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.RecoverPanics(true)
+	err := registry.BindWithType(reflect.TypeOf(""), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		panic("producer exploded")
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByType(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrRecoveredPanic)
+	assert.Contains(t, err.Error(), "producer exploded")
+	assert.Contains(t, err.Error(), "recover_test.go")
+}
+
+type PanickingService struct {
+}
+
+func (p *PanickingService) Init(registry *inject.Registry) error {
+	panic("init exploded")
+}
+
+func TestServiceLocator_RecoverPanicsInit(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.RecoverPanics(true)
+	if !assert.NoError(t, registry.Bind(&PanickingService{})) {
+		return
+	}
+
+	err := registry.Populate()
+	assert.ErrorIs(t, err, inject.ErrRecoveredPanic)
+	assert.Contains(t, err.Error(), "init exploded")
+}
+
+func TestServiceLocator_RecoverPanicsDisabled(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&PanickingService{})) {
+		return
+	}
+
+	assert.PanicsWithValue(t, "init exploded", func() {
+		_ = registry.Populate()
+	})
+}
@@ -0,0 +1,127 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InjectFieldsRecursive injects the annotated fields of target like InjectFields and then recurses into
+// the injected values, which are pointers to structs, so their annotated fields are injected as well.
+// Injected values referring back to a type already injected on the current path, fail with ErrCircularInjection.
+//
+// Exported fields without annotation are recursed into as well, if their type is a struct, or pointers to a struct,
+// with fields to inject, e.g. a nested config struct. Nil pointers, like a nil *C or **C, are allocated for that.
+// Other nil pointers are left alone, as are pointers to a type already on the current path, which would never end.
+func (r *Registry) InjectFieldsRecursive(target interface{}) error {
+	return r.injectFieldsRecursive(target, nil)
+}
+
+// injectFieldsRecursive injects target, chain are the types on the path from the outermost target to target.
+func (r *Registry) injectFieldsRecursive(target interface{}, chain []reflect.Type) error {
+	targetType := reflect.TypeOf(target)
+	for _, visited := range chain {
+		if visited == targetType {
+			return fmt.Errorf("%w: %s", ErrCircularInjection, typeChain(append(chain, targetType)))
+		}
+	}
+	if err := r.InjectFields(target); err != nil {
+		return err
+	}
+
+	chain = append(chain, targetType)
+	targetValue := reflect.ValueOf(target).Elem()
+	selector := r.selectorFor(targetValue.Type())
+	for i := 0; i < targetValue.NumField(); i++ {
+		field := targetValue.Type().Field(i)
+		var nested interface{}
+		var ok bool
+		if _, selected := selector(field); selected {
+			if isLazy(field.Type) {
+				continue
+			}
+			nested, ok = injectableValue(targetValue.Field(i))
+		} else if field.PkgPath == "" {
+			nested, ok = r.nestedInjectable(targetValue.Field(i), chain)
+		}
+		if ok {
+			if err := r.injectFieldsRecursive(nested, chain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nestedInjectable returns a pointer to the struct held by the unannotated field value, if the struct has fields
+// to inject. Nil pointers on the way to the struct are allocated, unless the struct is already on the chain.
+func (r *Registry) nestedInjectable(value reflect.Value, chain []reflect.Type) (interface{}, bool) {
+	structType := value.Type()
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct || !r.hasFieldsToInject(structType, map[reflect.Type]bool{}) {
+		return nil, false
+	}
+	if value.Kind() == reflect.Struct {
+		return value.Addr().Interface(), true
+	}
+	for _, visited := range chain {
+		if visited == reflect.PtrTo(structType) {
+			return nil, false
+		}
+	}
+
+	for {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		if value.Elem().Kind() != reflect.Ptr {
+			return value.Interface(), true
+		}
+		value = value.Elem()
+	}
+}
+
+// hasFieldsToInject reports whether structType, or an unannotated struct nested in it, has fields to inject.
+func (r *Registry) hasFieldsToInject(structType reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[structType] {
+		return false
+	}
+	visited[structType] = true
+
+	selector := r.selectorFor(structType)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if _, selected := selector(field); selected {
+			return true
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.PkgPath == "" && fieldType.Kind() == reflect.Struct && r.hasFieldsToInject(fieldType, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// injectableValue returns the pointer to a struct held by value, if any.
+func injectableValue(value reflect.Value) (interface{}, bool) {
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct || !value.CanInterface() {
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+func typeChain(chain []reflect.Type) string {
+	names := make([]string, len(chain))
+	for i, chainType := range chain {
+		names[i] = chainType.String()
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,87 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type RecursiveRepository struct {
+	DSN string `inject:"dsn"`
+}
+
+type RecursiveService struct {
+	Repository *RecursiveRepository `inject:""`
+}
+
+type RecursiveConfig struct {
+	DSN  string `inject:"dsn"`
+	Port int
+}
+
+type RecursiveServer struct {
+	Config   *RecursiveConfig
+	Fallback **RecursiveConfig
+	Plain    *RecursivePlain
+}
+
+type RecursivePlain struct {
+	Port int
+}
+
+type RecursiveCycleA struct {
+	B *RecursiveCycleB `inject:""`
+}
+
+type RecursiveCycleB struct {
+	A *RecursiveCycleA `inject:""`
+}
+
+func TestServiceLocator_InjectFieldsRecursive(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("dsn", "postgres://")) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&RecursiveRepository{})) {
+		return
+	}
+
+	service := &RecursiveService{}
+	if !assert.NoError(t, registry.InjectFieldsRecursive(service)) {
+		return
+	}
+	assert.Equal(t, "postgres://", service.Repository.DSN)
+}
+
+func TestServiceLocator_InjectFieldsRecursiveCycle(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&RecursiveCycleA{})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&RecursiveCycleB{})) {
+		return
+	}
+
+	err := registry.InjectFieldsRecursive(&RecursiveCycleA{})
+	assert.ErrorIs(t, err, inject.ErrCircularInjection)
+	assert.EqualError(t, err, "circular injection: *inject_test.RecursiveCycleA -> *inject_test.RecursiveCycleB -> *inject_test.RecursiveCycleA")
+}
+
+func TestServiceLocator_InjectFieldsRecursiveAllocatesNilPointers(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("dsn", "postgres://")) {
+		return
+	}
+
+	server := &RecursiveServer{}
+	if !assert.NoError(t, registry.InjectFieldsRecursive(server)) {
+		return
+	}
+	if assert.NotNil(t, server.Config) {
+		assert.Equal(t, "postgres://", server.Config.DSN)
+	}
+	if assert.NotNil(t, server.Fallback) && assert.NotNil(t, *server.Fallback) {
+		assert.Equal(t, "postgres://", (*server.Fallback).DSN)
+	}
+	assert.Nil(t, server.Plain)
+}
@@ -1,9 +1,15 @@
 package inject
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -12,6 +18,19 @@ var (
 	ErrInvalidInjectionType  = errors.New("invalid injection type")
 	ErrFieldNotSettable      = errors.New("field is not settable")
 	ErrInvalidProducer       = errors.New("invalid producer")
+	ErrProducerSkip          = errors.New("producer skipped")
+	ErrNotAFunction          = errors.New("not a function")
+	ErrAmbiguousBinding      = errors.New("ambiguous binding")
+	ErrCircularDependency    = errors.New("circular dependency")
+	ErrRecoveredPanic        = errors.New("recovered panic")
+	ErrDuplicateBinding      = errors.New("duplicate binding")
+	ErrNotInScope            = errors.New("not in scope")
+	ErrCircularInjection     = errors.New("circular injection")
+	ErrNilTarget             = fmt.Errorf("%w: target is nil", ErrInvalidInjectionPoint)
+	ErrTargetNotAPointer     = fmt.Errorf("%w: target is not a pointer", ErrInvalidInjectionPoint)
+	ErrUnsupportedTarget     = fmt.Errorf("%w: unsupported target kind", ErrInvalidInjectionPoint)
+	ErrProducerReturnedNil   = errors.New("producer returned nil")
+	ErrNotCached             = errors.New("binding is never cached")
 )
 
 type Producer interface {
@@ -28,22 +47,81 @@ type Service interface {
 	Init(locator *Registry) error
 }
 
+// AfterInject is implemented by injection targets, which need a hook after their fields were injected,
+// e.g. to validate them. It is the field injection analogue of Service.Init.
+type AfterInject interface {
+	AfterInject() error
+}
+
 type Registry struct {
-	log       *logrus.Entry
-	populated bool
-	entries   map[string]registryEntry
+	log               *logrus.Entry
+	mu                sync.RWMutex
+	populated         bool
+	population        *population
+	skipNonZero       bool
+	autoPointer       bool
+	resolveEmbedded   bool
+	traceResolution   bool
+	autoInjectOnBind  bool
+	recoverPanics     bool
+	bindPolicy        BindPolicy
+	interceptors      []ResolveInterceptor
+	targetInterceptor func(target interface{}) interface{}
+	fallbackProducer  Producer
+	injectables       []reflect.Type
+	tagKey            string
+	fieldSelector     FieldSelector
+	injectOptions     map[reflect.Type]InjectOptions
+	entries           map[string]*registryEntry
+	lastID            uint64
+	invalidateHooks   map[string][]func(name string)
+	// parent resolves the bindings missing in a child registry, see NewChild.
+	parent *Registry
+	// cacheHits and cacheMisses count resolutions of singletons, see CacheStats.
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+	// assignable caches isAssignableFrom, if configured with WithCacheSize.
+	assignable *lruCache[assignability, bool]
 }
 
 type registryEntry struct {
+	id        uint64
 	populated bool
 	source    interface{}
+	// declaredType is the type the entry was bound with, nil if bound by name only.
+	declaredType reflect.Type
+	// deps are names of bindings declared explicitly as dependencies.
+	deps []string
+	// validate checks the value on every resolution, see BindWithValidator.
+	validate func(value interface{}) error
+	// order is the init order, entries of the same order are initialized in insertion order.
+	order int
+	// shutdownOrder is the explicit dispose order, see BindWithShutdownOrder.
+	shutdownOrder int
+	// strategyKey is the key of strategies, see BindStrategy.
+	strategyKey interface{}
+
+	// singleton entries cache the first value produced by their producer.
+	singleton bool
+	// eager singletons are produced by Populate, see BindEager.
+	eager bool
+	// transient entries are explicitly excluded from caching, invalidating them fails, see BindTransient.
+	transient bool
+	produced  bool
+	cached    interface{}
+	// generation counts the invalidations, a value produced before one of them is not cached.
+	generation uint64
+	// producing is closed, once the singleton being produced is produced.
+	producing chan struct{}
 }
 
 func NewRegistry() *Registry {
 	return &Registry{
-		log:       logrus.WithField("module", "Registry"),
-		populated: false,
-		entries:   make(map[string]registryEntry),
+		log:             logrus.WithField("module", "Registry"),
+		populated:       false,
+		tagKey:          "inject",
+		entries:         make(map[string]*registryEntry),
+		invalidateHooks: make(map[string][]func(name string)),
 	}
 }
 
@@ -57,12 +135,18 @@ func (r *Registry) MustBind(service interface{}) {
 	}
 }
 
+// BindWithType binds entry for expectedType. A producer created with NewTypedProducer must declare a type
+// assignable to expectedType. Other producers are bound as producing expectedType, each value they produce
+// is checked against it and resolving a value of another type fails with ErrInvalidProducer.
 func (r *Registry) BindWithType(expectedType reflect.Type, entry interface{}) error {
-	actualType := reflect.TypeOf(entry)
-	if !r.isAssignableFrom(expectedType, actualType) {
-		return ErrInvalidInjectionType
+	if !r.isBindableAs(expectedType, entry) {
+		return &TypeMismatchError{Expected: expectedType, Actual: reflect.TypeOf(entry)}
 	}
-	return r.BindWithName(expectedType.String(), entry)
+	return r.bind(expectedType.String(), &registryEntry{
+		populated:    false,
+		source:       entry,
+		declaredType: expectedType,
+	})
 }
 
 func (r *Registry) MustBindWithType(expectedType reflect.Type, entry interface{}) {
@@ -71,54 +155,427 @@ func (r *Registry) MustBindWithType(expectedType reflect.Type, entry interface{}
 	}
 }
 
+// BindImplemented binds value under its own type and additionally under each of the passed interfaces,
+// so the same instance is resolvable by all of them. Nothing is bound, if value doesn't implement one of the interfaces.
+func (r *Registry) BindImplemented(value interface{}, ifaces ...reflect.Type) error {
+	actualType := reflect.TypeOf(value)
+	for _, iface := range ifaces {
+		if iface.Kind() != reflect.Interface || !actualType.Implements(iface) {
+			return fmt.Errorf("%w: %s does not implement %s", ErrInvalidInjectionType, actualType, iface)
+		}
+	}
+
+	if err := r.BindWithType(actualType, value); err != nil {
+		return err
+	}
+	for _, iface := range ifaces {
+		if err := r.BindWithType(iface, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Registry) BindWithName(name string, entry interface{}) error {
-	r.entries[name] = registryEntry{
+	return r.bind(name, &registryEntry{
 		populated: false,
 		source:    entry,
+	})
+}
+
+// BindWithNameTyped binds value under name like BindWithName, but records declaredType.
+// value must be assignable to declaredType, and resolutions must request a type declaredType is assignable to,
+// regardless of the dynamic type of value.
+func (r *Registry) BindWithNameTyped(name string, declaredType reflect.Type, value interface{}) error {
+	if !r.isBindableAs(declaredType, value) {
+		return fmt.Errorf("%w: %T is not assignable to %s", ErrInvalidInjectionType, value, declaredType)
 	}
-	return nil
+	return r.bind(name, &registryEntry{
+		populated:    false,
+		source:       value,
+		declaredType: declaredType,
+	})
+}
+
+// BindWithValidator binds value under name like BindWithName and runs validate on every resolution.
+// If validate fails, e.g. because the value became stale, the resolution fails with its error.
+// Values of producers are validated after production.
+func (r *Registry) BindWithValidator(name string, value interface{}, validate func(value interface{}) error) error {
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    value,
+		validate:  validate,
+	})
+}
+
+// BindWithNameAndType binds value under name and additionally for expectedType,
+// so the same instance is resolvable by GetByName and GetByType.
+func (r *Registry) BindWithNameAndType(name string, expectedType reflect.Type, value interface{}) error {
+	if !r.isBindableAs(expectedType, value) {
+		return fmt.Errorf("%w: %T is not assignable to %s", ErrInvalidInjectionType, value, expectedType)
+	}
+
+	r.mu.Lock()
+	names := []string{name, expectedType.String()}
+	admitted := make([]bool, len(names))
+	for i, name := range names {
+		var err error
+		if admitted[i], err = r.admits(name); err != nil {
+			r.mu.Unlock()
+			return err
+		}
+	}
+	var bound []boundEntry
+	for i, name := range names {
+		if admitted[i] {
+			bound = append(bound, r.putBound(name, &registryEntry{
+				populated:    false,
+				source:       value,
+				declaredType: expectedType,
+			}))
+		}
+	}
+	r.mu.Unlock()
+	return r.injectBound(bound, value)
+}
+
+// ResolveWith layers the temp bindings over the registry for the duration of fn.
+// Afterwards the temporary bindings are removed and the overlaid bindings are restored.
+// As the bindings are visible to every user of the registry while fn runs, prefer a dedicated registry for concurrent use.
+func (r *Registry) ResolveWith(temp map[string]interface{}, fn func(r *Registry) error) error {
+	previous := make(map[string]*registryEntry, len(temp))
+	r.mu.Lock()
+	for name, source := range temp {
+		previous[name] = r.entries[name]
+		r.put(name, &registryEntry{
+			populated: false,
+			source:    source,
+		})
+	}
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for name, entry := range previous {
+			if entry == nil {
+				delete(r.entries, name)
+			} else {
+				r.entries[name] = entry
+			}
+		}
+	}()
+
+	return fn(r)
+}
+
+func (r *Registry) bind(name string, entry *registryEntry) error {
+	r.mu.Lock()
+	admitted, err := r.admits(name)
+	var bound []boundEntry
+	if admitted {
+		bound = append(bound, r.putBound(name, entry))
+	}
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return r.injectBound(bound, entry.source)
+}
+
+// put stores entry under name, identifying it with a new id. The caller must hold the lock.
+func (r *Registry) put(name string, entry *registryEntry) {
+	r.lastID++
+	entry.id = r.lastID
+	r.entries[name] = entry
+}
+
+// boundEntry records an entry put under name and the entry it replaced, if any, so it can be rolled back.
+type boundEntry struct {
+	name     string
+	entry    *registryEntry
+	replaced *registryEntry
+}
+
+// putBound works like put, but returns the record to roll the entry back. The caller must hold the lock.
+func (r *Registry) putBound(name string, entry *registryEntry) boundEntry {
+	replaced := r.entries[name]
+	r.put(name, entry)
+	return boundEntry{name: name, entry: entry, replaced: replaced}
+}
+
+func (r *Registry) entry(name string) (*registryEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, exists := r.entries[name]
+	return entry, exists
+}
+
+// snapshot returns a copy of the current entries, so they can be processed without holding the lock.
+func (r *Registry) snapshot() map[string]*registryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := make(map[string]*registryEntry, len(r.entries))
+	for name, entry := range r.entries {
+		entries[name] = entry
+	}
+	return entries
 }
 
 func (r *Registry) GetByType(expectedType reflect.Type) (interface{}, error) {
-	name := expectedType.String()
-	return r.GetByName(name, expectedType)
+	return r.getByType(expectedType, resolution{})
 }
 
-func (r *Registry) getByType(expectedType reflect.Type, source interface{}) (interface{}, error) {
-	name := expectedType.String()
-	return r.getByName(name, source, expectedType)
+// ResolveValue resolves the binding for expectedType like GetByType, but returns it as reflect.Value.
+// This is meant for frameworks building their own kind of injection on top of the registry.
+func (r *Registry) ResolveValue(expectedType reflect.Type) (reflect.Value, error) {
+	value, err := r.getByType(expectedType, resolution{})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(value), nil
+}
+
+func (r *Registry) getByType(expectedType reflect.Type, res resolution) (interface{}, error) {
+	res, attachTrace := r.traced(res)
+	value, err := r.lookupByType(expectedType, res)
+	if err != nil {
+		value, err = r.fallback(expectedType.String(), expectedType, res, err)
+	}
+	if err != nil {
+		return nil, attachTrace(err)
+	}
+
+	if !r.isAssignableFrom(expectedType, reflect.TypeOf(value)) {
+		return nil, &TypeMismatchError{Expected: expectedType, Actual: reflect.TypeOf(value)}
+	}
+	return value, nil
 }
 
 func (r *Registry) GetByName(name string, expectedType reflect.Type) (interface{}, error) {
-	return r.getByName(name, nil, expectedType)
+	return r.getByName(name, expectedType, resolution{})
 }
 
-func (r *Registry) getByName(name string, source interface{}, expectedType reflect.Type) (interface{}, error) {
-	entry, exists := r.entries[name]
+// GetByTypeFrom works like GetByType, but passes source to the producers.
+func (r *Registry) GetByTypeFrom(source interface{}, expectedType reflect.Type) (interface{}, error) {
+	return r.getByType(expectedType, resolution{source: source})
+}
+
+// GetByNameFrom works like GetByName, but passes source to the producers.
+func (r *Registry) GetByNameFrom(source interface{}, name string, expectedType reflect.Type) (interface{}, error) {
+	return r.getByName(name, expectedType, resolution{source: source})
+}
+
+func (r *Registry) getByName(name string, expectedType reflect.Type, res resolution) (interface{}, error) {
+	res, attachTrace := r.traced(res)
+	value, err := r.lookup(name, expectedType, res)
+	if err != nil {
+		value, err = r.fallback(name, expectedType, res, err)
+	}
+	if err != nil {
+		return nil, attachTrace(err)
+	}
+
+	if !r.isAssignableFrom(expectedType, reflect.TypeOf(value)) {
+		return nil, &TypeMismatchError{Name: name, Expected: expectedType, Actual: reflect.TypeOf(value)}
+	}
+	return value, nil
+}
+
+// resolution carries the options of a single resolution through the lookup.
+type resolution struct {
+	// source is passed to producers.
+	source interface{}
+	// fresh bypasses the cache of singletons.
+	fresh bool
+	// ctx is passed to context aware producers, nil if the resolution has no context.
+	ctx context.Context
+	// trace records the looked up names, if TraceResolution is enabled.
+	trace *resolutionTrace
+	// constructing are the constructors in flight, to detect cyclic constructors.
+	constructing *constructionPath
+	// probe resolutions only look up bindings, the fallback producer is not consulted.
+	probe bool
+	// scope resolves the bindings scoped to it, nil outside of a Scope.
+	scope *Scope
+}
+
+// lookup returns the value bound under name, invoking its producer unless expectedType is bound directly.
+// The result is not checked against expectedType.
+func (r *Registry) lookup(name string, expectedType reflect.Type, res resolution) (interface{}, error) {
+	res.trace.add(name)
+	entry, exists := r.entry(name)
 	if !exists {
-		return nil, ErrEntryNotFound
+		return nil, &NotFoundError{Name: name}
 	}
 
-	actualSource := entry.source
-	actualType := reflect.TypeOf(actualSource)
-	if actualType != expectedType {
-		producer, isProducer := actualSource.(Producer)
-		if isProducer {
-			producedSource, err := producer.Produce(source, expectedType)
-			if err != nil {
-				return nil, err
-			}
+	if entry.declaredType != nil && !isDeclaredAs(entry.declaredType, expectedType) {
+		return nil, fmt.Errorf("%w: %q is declared as %s, requested as %s",
+			ErrInvalidInjectionType, name, entry.declaredType, expectedType)
+	}
 
-			actualSource = producedSource
-			actualType = reflect.TypeOf(actualSource)
+	res, err := res.construct(entry, name)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if scoped, ok := entry.source.(scopedProducer); ok && res.scope != nil && res.scope.scope == scoped.scope {
+		value, err = res.scope.instance(r, name, scoped.producer, expectedType, res)
+	} else {
+		value, err = r.value(entry, expectedType, res)
+	}
+	if errors.Is(err, ErrProducerSkip) {
+		return nil, &NotFoundError{Name: name}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.validate != nil {
+		if err := r.protect(func() error { return entry.validate(value) }); err != nil {
+			return nil, fmt.Errorf("validating %q: %w", name, err)
 		}
+	}
+	return r.intercept(name, value)
+}
 
-		if !r.isAssignableFrom(expectedType, actualType) {
-			return nil, ErrInvalidInjectionType
+// value returns the source of entry, or the value produced by it unless expectedType is bound directly.
+func (r *Registry) value(entry *registryEntry, expectedType reflect.Type, res resolution) (interface{}, error) {
+	if reflect.TypeOf(entry.source) == expectedType {
+		return entry.source, nil
+	}
+
+	producer, isProducer := entry.source.(Producer)
+	if !isProducer {
+		return entry.source, nil
+	}
+	value, err := r.produce(entry, producer, expectedType, res)
+	if err != nil {
+		return nil, err
+	}
+	if entry.declaredType != nil && !r.isAssignableFrom(entry.declaredType, reflect.TypeOf(value)) {
+		// the binding declares what the producer produces
+		return nil, fmt.Errorf("%w: produced %T for %s", ErrInvalidProducer, value, entry.declaredType)
+	}
+	return value, nil
+}
+
+// lookupByType looks up the binding for expectedType. If there is none and an interface is expected,
+// the only binding implementing the interface is used instead. Otherwise, if enabled, the embedded struct
+// of the only binding embedding expectedType is used, see ResolveEmbedded.
+// Child registries without any of these resolve expectedType from their parent the same way.
+func (r *Registry) lookupByType(expectedType reflect.Type, res resolution) (interface{}, error) {
+	value, err := r.lookup(expectedType.String(), expectedType, res)
+	if !errors.Is(err, ErrEntryNotFound) {
+		return value, err
+	}
+
+	if expectedType.Kind() == reflect.Interface {
+		var name string
+		name, err = r.implementer(expectedType)
+		if err == nil {
+			return r.lookup(name, expectedType, res)
+		}
+	} else if r.resolveEmbedded {
+		value, err = r.embedded(expectedType)
+		if err == nil {
+			return value, nil
+		}
+	}
+	if r.parent != nil && isMissing(err, expectedType.String()) {
+		return r.parent.lookupByType(expectedType, res)
+	}
+	return nil, err
+}
+
+// implementer returns the name of the only binding implementing iface.
+// Every binding implements an empty interface, like interface{}, so none is used for it.
+func (r *Registry) implementer(iface reflect.Type) (string, error) {
+	if iface.NumMethod() == 0 {
+		return "", &NotFoundError{Name: iface.String()}
+	}
+	candidates := r.implementing(iface)
+	switch len(candidates) {
+	case 0:
+		return "", &NotFoundError{Name: iface.String()}
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("%w: %s is implemented by %s",
+			ErrAmbiguousBinding, iface, strings.Join(candidates, ", "))
+	}
+}
+
+// implementing returns the sorted names of all bindings implementing iface.
+// Producers are only considered, if they were bound with a declared type.
+// A value bound under several names is only reported once.
+func (r *Registry) implementing(iface reflect.Type) []string {
+	var names []string
+	for name, entry := range uniqueEntries(r.snapshot()) {
+		entryType := entry.declaredType
+		if entryType == nil {
+			if isProducer(entry.source) {
+				continue
+			}
+			entryType = reflect.TypeOf(entry.source)
+		}
+		if entryType.Implements(iface) {
+			names = append(names, name)
 		}
 	}
+	sort.Strings(names)
+	return names
+}
+
+// produce invokes the producer of entry. Singleton entries are served from their cache once produced,
+// unless a fresh value is requested. Concurrent resolutions of a singleton wait for the one producing it,
+// so its producer runs once, unless production fails.
+func (r *Registry) produce(entry *registryEntry, producer Producer, expectedType reflect.Type, res resolution) (interface{}, error) {
+	if res.fresh || !entry.singleton {
+		return r.invokeProducer(producer, res, expectedType)
+	}
+	// singletons outlive every Scope, so they can't depend on the bindings scoped to one
+	res.scope = nil
+
+	r.mu.Lock()
+	for entry.producing != nil && !entry.produced {
+		producing := entry.producing
+		r.mu.Unlock()
+		<-producing
+		r.mu.Lock()
+	}
+	if entry.produced {
+		cached := entry.cached
+		r.mu.Unlock()
+		r.cacheHits.Add(1)
+		return cached, nil
+	}
+	producing := make(chan struct{})
+	entry.producing = producing
+	generation := entry.generation
+	r.mu.Unlock()
+	r.cacheMisses.Add(1)
+	defer func() {
+		// release the waiting resolutions, even if the producer panics
+		r.mu.Lock()
+		entry.producing = nil
+		r.mu.Unlock()
+		close(producing)
+	}()
+
+	value, err := r.invokeProducer(producer, res, expectedType)
+	if err != nil {
+		return nil, err
+	}
 
-	return actualSource, nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry.generation == generation {
+		// otherwise invalidated while producing, the value may be stale already
+		entry.cached = value
+		entry.produced = true
+	}
+	return value, nil
 }
 
 func (r *Registry) isAssignableFrom(expectedType, actualType reflect.Type) bool {
@@ -126,15 +583,45 @@ func (r *Registry) isAssignableFrom(expectedType, actualType reflect.Type) bool
 		// actualType is the same as expected
 		return true
 	}
+	if r.assignable == nil {
+		return isAssignable(expectedType, actualType)
+	}
 
+	key := assignability{expectedType: expectedType, actualType: actualType}
+	if assignable, cached := r.assignable.get(key); cached {
+		return assignable
+	}
+	assignable := isAssignable(expectedType, actualType)
+	r.assignable.put(key, assignable)
+	return assignable
+}
+
+// isAssignable reports whether a value of actualType may be injected as expectedType, other than by identity.
+func isAssignable(expectedType, actualType reflect.Type) bool {
 	if (expectedType.Kind() == reflect.Interface && actualType.Implements(expectedType)) ||
 		(expectedType.Kind() == reflect.Ptr && expectedType.Elem().Kind() == reflect.Interface && actualType.Implements(expectedType.Elem())) {
 		// an interface is expected and actualType implements it
 		return true
 	}
 
-	if actualType.Implements(reflect.TypeOf((*Producer)(nil)).Elem()) {
-		// actualType is a producer
+	if expectedType.Kind() == reflect.Func && actualType.AssignableTo(expectedType) {
+		// a function with a matching signature is expected
+		return true
+	}
+	return false
+}
+
+// isBindableAs reports whether entry may be bound as expectedType.
+// Producers are checked by the type they declare to produce, if any. Otherwise the values they produce
+// are checked against expectedType on resolution.
+func (r *Registry) isBindableAs(expectedType reflect.Type, entry interface{}) bool {
+	if r.isAssignableFrom(expectedType, reflect.TypeOf(entry)) {
+		return true
+	}
+	switch producer := entry.(type) {
+	case typedProducer:
+		return r.isAssignableFrom(expectedType, producer.producedType)
+	case Producer:
 		return true
 	}
 	return false
@@ -145,22 +632,55 @@ func (r *Registry) isAssignableFrom(expectedType, actualType reflect.Type) bool
 // Caller is the calling struct, this is passed to the producers if there are any.
 func (r *Registry) InjectFrom(caller interface{}, targets ...interface{}) error {
 	for _, target := range targets {
-		targetPtr := reflect.TypeOf(target)
-		if targetPtr.Kind() != reflect.Ptr {
-			return ErrInvalidInjectionPoint
-		}
-
-		actualValue, err := r.getByType(targetPtr.Elem(), caller)
-		if err != nil {
+		if err := r.injectTarget(caller, target); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		serviceValue := reflect.ValueOf(target).Elem()
-		if !serviceValue.CanSet() {
-			return ErrFieldNotSettable
+// InjectFromBestEffort works like InjectFrom, but doesn't stop at the first failing target.
+// All targets which can be resolved are injected, the failures are returned joined.
+func (r *Registry) InjectFromBestEffort(caller interface{}, targets ...interface{}) error {
+	var errs []error
+	for i, target := range targets {
+		if err := r.injectTarget(caller, target); err != nil {
+			errs = append(errs, fmt.Errorf("target %d of type %T: %w", i, target, err))
 		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) injectTarget(caller interface{}, target interface{}) error {
+	target = r.interceptTarget(target)
+	if err := checkTarget(target); err != nil {
+		return err
+	}
+
+	actualValue, err := r.getByType(reflect.TypeOf(target).Elem(), resolution{source: caller})
+	if err != nil {
+		return err
+	}
+
+	reflect.ValueOf(target).Elem().Set(reflect.ValueOf(actualValue))
+	return nil
+}
 
-		serviceValue.Set(reflect.ValueOf(actualValue))
+// checkTarget reports why target can't be injected into by InjectFrom, if it can't.
+// The value a non-nil pointer points to is always settable, so there is no separate check for that.
+func checkTarget(target interface{}) error {
+	if target == nil {
+		return ErrNilTarget
+	}
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: %T", ErrTargetNotAPointer, target)
+	}
+	if targetValue.IsNil() {
+		return fmt.Errorf("%w: %T", ErrNilTarget, target)
+	}
+	if targetValue.Elem().Kind() == reflect.UnsafePointer {
+		return fmt.Errorf("%w: %T", ErrUnsupportedTarget, target)
 	}
 	return nil
 }
@@ -171,9 +691,86 @@ func (r *Registry) Inject(targets ...interface{}) error {
 	return r.InjectFrom(nil, targets...)
 }
 
+// SetTagKey changes the struct tag key used to annotate fields for injection, which defaults to "inject".
+func (r *Registry) SetTagKey(key string) {
+	r.tagKey = key
+}
+
+// SkipNonZero configures InjectFields to leave fields alone, which already hold a non-zero value.
+// This way manually wired fields survive the injection.
+func (r *Registry) SkipNonZero(skip bool) {
+	r.skipNonZero = skip
+}
+
+// AutoPointer configures field injection to satisfy a *T field with a bound T, if there is no binding for *T.
+// The field points to a copy of the bound value then, so changes through the field don't affect the binding.
+func (r *Registry) AutoPointer(enabled bool) {
+	r.autoPointer = enabled
+}
+
 // InjectFields injects the registered bindings into the annotated fields of target.
 // Therefore target must be a pointer to a struct, containing exported fields annotated with 'inject'.
+// Embedded fields are treated like named fields, so an annotated embedded interface receives the implementation.
+// Fields of types registered with SetInjectOptions are injected as specified there as well.
 func (r *Registry) InjectFields(target interface{}) error {
+	return r.protect(func() error {
+		target := r.interceptTarget(target)
+		return r.injectFields(target, r.selectorFor(SourceType(target)), false, r.skipNonZero, nil)
+	})
+}
+
+// RefreshFields works like InjectFields, but only injects fields which are still zero, e.g. nil.
+// Fields set before, manually or by an earlier injection, are left alone. This way the remaining fields
+// can be filled after further bindings were registered, regardless of SkipNonZero.
+func (r *Registry) RefreshFields(target interface{}) error {
+	return r.protect(func() error {
+		target := r.interceptTarget(target)
+		return r.injectFields(target, r.selectorFor(SourceType(target)), false, true, nil)
+	})
+}
+
+// FillStruct injects the registered bindings into all exported fields of target, regardless of annotations.
+// Fields are resolved by type, unless annotated with a name. Fields without a matching binding are skipped.
+// Therefore target must be a pointer to a struct.
+func (r *Registry) FillStruct(target interface{}) error {
+	return r.protect(func() error {
+		return r.injectFields(target, r.exportedSelector, true, r.skipNonZero, nil)
+	})
+}
+
+// FieldSelector decides whether a field gets injected and under which name. An empty name injects by type.
+// Like in tags, the name may be followed by comma separated options, e.g. "name,new".
+type FieldSelector func(field reflect.StructField) (name string, inject bool)
+
+// SetFieldSelector replaces the annotation based selection of fields by InjectFields with selector.
+// Passing nil restores the default, which selects fields annotated with the tag key.
+func (r *Registry) SetFieldSelector(selector FieldSelector) {
+	r.fieldSelector = selector
+}
+
+// selectField applies the configured FieldSelector.
+func (r *Registry) selectField(field reflect.StructField) (string, bool) {
+	if r.fieldSelector != nil {
+		return r.fieldSelector(field)
+	}
+	return r.tagSelector(field)
+}
+
+func (r *Registry) tagSelector(field reflect.StructField) (string, bool) {
+	return field.Tag.Lookup(r.tagKey)
+}
+
+func (r *Registry) exportedSelector(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag, _ := field.Tag.Lookup(r.tagKey)
+	return tag, true
+}
+
+// injectFields injects the fields of target chosen by selector. Bindings scoped to scope resolve to its instances,
+// scope is nil outside of a Scope.
+func (r *Registry) injectFields(target interface{}, selector FieldSelector, skipMissing, skipNonZero bool, scope *Scope) error {
 	targetType := reflect.TypeOf(target)
 	if targetType.Kind() != reflect.Ptr || targetType.Elem().Kind() != reflect.Struct {
 		return ErrInvalidInjectionPoint
@@ -183,53 +780,176 @@ func (r *Registry) InjectFields(target interface{}) error {
 	targetValue := reflect.ValueOf(target).Elem()
 	for i := 0; i < targetType.NumField(); i++ {
 		field := targetType.Field(i)
-		tag, ok := field.Tag.Lookup("inject")
+		tag, ok := selector(field)
 		if !ok {
 			continue
 		}
+		if skipNonZero && !targetValue.Field(i).IsZero() {
+			continue
+		}
 
+		name, options := parseTag(tag)
+		if isLazy(field.Type) {
+			if !targetValue.Field(i).CanSet() {
+				return fmt.Errorf("%w: %s", ErrFieldNotSettable, field.Name)
+			}
+			targetValue.Field(i).Set(r.lazyValue(field.Type, name, target))
+			continue
+		}
+		res, attachTrace := r.traced(resolution{
+			source: target,
+			fresh:  options.has("new"),
+			scope:  scope,
+		})
 		var fieldValue interface{}
-		if tag == "" {
-			value, err := r.getByType(field.Type, target)
-			if err != nil {
-				return err
+		err := ErrEntryNotFound
+		if options.has("byconsumer") {
+			probe := res
+			probe.probe = true
+			fieldValue, err = r.resolveField(field, SourceType(target).String(), probe)
+			if err == nil {
+				name = SourceType(target).String()
 			}
-			fieldValue = value
-		} else {
-			value, err := r.getByName(tag, target, field.Type)
-			if err != nil {
-				return err
+		}
+		if errors.Is(err, ErrEntryNotFound) {
+			fieldValue, err = r.resolveField(field, name, res)
+		}
+		if errors.Is(err, ErrEntryNotFound) {
+			if options.has("default") {
+				if !targetValue.Field(i).CanSet() {
+					return fmt.Errorf("%w: %s", ErrFieldNotSettable, field.Name)
+				}
+				targetValue.Field(i).Set(defaultValue(field.Type))
+				continue
+			}
+			if skipMissing || options.has("optional") {
+				continue
 			}
-			fieldValue = value
+		}
+		if err != nil {
+			return attachTrace(err)
 		}
 
-		targetValue.Field(i).Set(reflect.ValueOf(fieldValue))
+		if err := r.setField(field, name, targetValue.Field(i), fieldValue); err != nil {
+			return err
+		}
+		if options.has("deep") {
+			if err := r.injectMapValues(field, fieldValue); err != nil {
+				return err
+			}
+		}
 	}
 
+	if afterInject, ok := target.(AfterInject); ok {
+		return afterInject.AfterInject()
+	}
 	return nil
 }
 
-// Populate calls InjectFields for every registered struct and Init() on all registered bindings,
-// implementing the inject.Service interface.
-func (r *Registry) Populate() error {
-	if r.populated {
-		r.log.Warn("Service locator is already populated")
-		return nil
+// resolveField looks up the value for field under name, or by the field type if name is empty.
+// A name like "prefix:handlers." collects all bindings starting with the prefix into a map.
+func (r *Registry) resolveField(field reflect.StructField, name string, res resolution) (interface{}, error) {
+	if prefix, ok := strings.CutPrefix(name, "prefix:"); ok {
+		return r.resolvePrefix(field.Type, prefix, res)
 	}
-	for _, entry := range r.entries {
-		serviceType := reflect.TypeOf(entry.source)
-		if serviceType.Kind() == reflect.Ptr && serviceType.Elem().Kind() == reflect.Struct {
-			if err := r.InjectFields(entry.source); err != nil {
-				return err
-			}
+	value, err := r.resolveType(field.Type, name, res)
+	if err != nil && r.autoPointer && field.Type.Kind() == reflect.Ptr && !isInterfacePointer(field.Type) {
+		// a *T field is satisfied by a copy of a bound T
+		if elemValue, elemErr := r.resolveType(field.Type.Elem(), name, res); elemErr == nil {
+			return elemValue, nil
 		}
+	}
+	return value, err
+}
 
-		service, ok := entry.source.(Service)
-		if ok {
-			if err := service.Init(r); err != nil {
-				return err
+// resolveType looks up the value for fieldType under name, or by fieldType if name is empty.
+func (r *Registry) resolveType(fieldType reflect.Type, name string, res resolution) (interface{}, error) {
+	if name != "" {
+		value, err := r.lookup(name, fieldType, res)
+		if err != nil {
+			return r.fallback(name, fieldType, res, err)
+		}
+		return value, nil
+	}
+
+	value, err := r.lookupByType(fieldType, res)
+	if errors.Is(err, ErrEntryNotFound) && isInterfacePointer(fieldType) {
+		// a *Iface field is satisfied by a binding for Iface
+		value, err = r.lookupByType(fieldType.Elem(), res)
+		if err != nil {
+			return r.fallback(fieldType.Elem().String(), fieldType, res, err)
+		}
+	}
+	if err != nil {
+		return r.fallback(fieldType.String(), fieldType, res, err)
+	}
+	return value, nil
+}
+
+// injectMapValues injects the fields of all values of the map injected into field, which are pointers to structs.
+func (r *Registry) injectMapValues(field reflect.StructField, value interface{}) error {
+	mapValue := reflect.ValueOf(value)
+	if mapValue.Kind() != reflect.Map {
+		return fmt.Errorf("%w: field %s of type %s can't be injected deep, it isn't a map",
+			ErrInvalidInjectionPoint, field.Name, field.Type)
+	}
+	for iter := mapValue.MapRange(); iter.Next(); {
+		if element, ok := injectableValue(iter.Value()); ok {
+			if err := r.InjectFields(element); err != nil {
+				return fmt.Errorf("field %s, key %v: %w", field.Name, iter.Key(), err)
 			}
 		}
 	}
 	return nil
 }
+
+// setField assigns value to the field, after validating that it is settable and the types are compatible.
+// name is the name of the binding value was resolved from, empty if resolved by type.
+func (r *Registry) setField(field reflect.StructField, name string, fieldValue reflect.Value, value interface{}) error {
+	if !fieldValue.CanSet() {
+		// e.g. unexported fields
+		return fmt.Errorf("%w: %s", ErrFieldNotSettable, field.Name)
+	}
+
+	actualType := reflect.TypeOf(value)
+	if (isInterfacePointer(field.Type) || r.autoPointer && field.Type.Kind() == reflect.Ptr) &&
+		!actualType.AssignableTo(field.Type) && actualType.AssignableTo(field.Type.Elem()) {
+		// allocate the interface or a copy of the value and point the field to it
+		pointer := reflect.New(field.Type.Elem())
+		pointer.Elem().Set(reflect.ValueOf(value))
+		fieldValue.Set(pointer)
+		return nil
+	}
+
+	if !actualType.AssignableTo(field.Type) {
+		return fmt.Errorf("field %s: %w", field.Name, &TypeMismatchError{Name: name, Expected: field.Type, Actual: actualType})
+	}
+
+	fieldValue.Set(reflect.ValueOf(value))
+	return nil
+}
+
+// defaultValue returns the value for a field of fieldType without binding. Other than the zero value,
+// pointers point to a zero instance and maps are empty, but not nil.
+func defaultValue(fieldType reflect.Type) reflect.Value {
+	switch fieldType.Kind() {
+	case reflect.Ptr:
+		return reflect.New(fieldType.Elem())
+	case reflect.Map:
+		return reflect.MakeMap(fieldType)
+	default:
+		return reflect.Zero(fieldType)
+	}
+}
+
+// isDeclaredAs reports whether a binding declared as declaredType may be requested as expectedType.
+func isDeclaredAs(declaredType, expectedType reflect.Type) bool {
+	if declaredType.AssignableTo(expectedType) {
+		return true
+	}
+	return isInterfacePointer(expectedType) && declaredType.AssignableTo(expectedType.Elem())
+}
+
+func isInterfacePointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface
+}
@@ -1,10 +1,14 @@
 package inject_test
 
 import (
+	"errors"
 	"github.com/dreske/go-inject"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
 type SimpleTestInterface interface {
@@ -69,7 +73,7 @@ func TestServiceLocator_BindWithNameWrongType(t *testing.T) {
 	}
 
 	_, err := registry.GetByName("MyCustomName", reflect.TypeOf(1))
-	assert.Equal(t, inject.ErrInvalidInjectionType, err)
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
 }
 
 func TestServiceLocator_BindWithType(t *testing.T) {
@@ -172,7 +176,7 @@ func TestServiceLocator_SimpleInjectInvalidPointer(t *testing.T) {
 	}
 
 	var test string
-	assert.Equal(t, inject.ErrInvalidInjectionPoint, registry.Inject(test))
+	assert.ErrorIs(t, registry.Inject(test), inject.ErrInvalidInjectionPoint)
 }
 
 func TestServiceLocator_BindProducer(t *testing.T) {
@@ -192,3 +196,834 @@ func TestServiceLocator_BindProducer(t *testing.T) {
 
 	assert.Equal(t, "Hello World", result)
 }
+
+func TestServiceLocator_InjectFunctionField(t *testing.T) {
+	type Request struct {
+		Path string
+	}
+	type Response struct {
+		Body string
+	}
+	type Handler func(Request) Response
+
+	type InjectInto struct {
+		Handler Handler `inject:"handlerFactory"`
+	}
+
+	registry := inject.NewRegistry()
+	greeting := "Hello"
+	err := registry.BindWithName("handlerFactory", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return func(request Request) Response {
+			return Response{Body: greeting + " " + request.Path}
+		}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	if !assert.NotNil(t, injectInto.Handler) {
+		return
+	}
+	assert.Equal(t, "Hello /index", injectInto.Handler(Request{Path: "/index"}).Body)
+}
+
+func TestServiceLocator_InjectFunctionFieldWrongSignature(t *testing.T) {
+	type InjectInto struct {
+		Handler func(string) string `inject:"handlerFactory"`
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindWithName("handlerFactory", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		return func(int) int { return 0 }, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.ErrorIs(t, registry.InjectFields(&InjectInto{}), inject.ErrInvalidInjectionType)
+}
+
+func TestServiceLocator_InjectFieldsSkipNonZero(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type InjectInto struct {
+		Manual   *Injected `inject:""`
+		Injected *Injected `inject:"Injected"`
+	}
+
+	registry := inject.NewRegistry()
+	registry.SkipNonZero(true)
+	if !assert.NoError(t, registry.Bind(&Injected{name: "ByType"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("Injected", &Injected{name: "ByName"})) {
+		return
+	}
+
+	manual := &Injected{name: "Manual"}
+	injectInto := InjectInto{Manual: manual}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	assert.Same(t, manual, injectInto.Manual)
+	if assert.NotNil(t, injectInto.Injected) {
+		assert.Equal(t, "ByName", injectInto.Injected.name)
+	}
+}
+
+func TestServiceLocator_RefreshFields(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type InjectInto struct {
+		Manual *Injected `inject:""`
+		Late   *Injected `inject:"Late,optional"`
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&Injected{name: "ByType"})) {
+		return
+	}
+
+	manual := &Injected{name: "Manual"}
+	injectInto := InjectInto{Manual: manual}
+	if !assert.NoError(t, registry.RefreshFields(&injectInto)) {
+		return
+	}
+	assert.Same(t, manual, injectInto.Manual)
+	assert.Nil(t, injectInto.Late)
+
+	if !assert.NoError(t, registry.BindWithName("Late", &Injected{name: "Late"})) {
+		return
+	}
+	if !assert.NoError(t, registry.RefreshFields(&injectInto)) {
+		return
+	}
+	assert.Same(t, manual, injectInto.Manual)
+	if assert.NotNil(t, injectInto.Late) {
+		assert.Equal(t, "Late", injectInto.Late.name)
+	}
+}
+
+func TestServiceLocator_InjectFieldsKindMismatch(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type InjectInto struct {
+		Count int `inject:"Injected"`
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("Injected", Injected{name: "Injected"})) {
+		return
+	}
+
+	err := registry.InjectFields(&InjectInto{})
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	assert.EqualError(t, err, `field Count: invalid injection type: "Injected" of type inject_test.Injected is not assignable to int`)
+
+	var mismatch *inject.TypeMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, "Injected", mismatch.Name)
+		assert.Equal(t, reflect.TypeOf(0), mismatch.Expected)
+		assert.Equal(t, reflect.TypeOf(Injected{}), mismatch.Actual)
+	}
+}
+
+func TestServiceLocator_ResolveWith(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("Overlaid", "Original")) {
+		return
+	}
+
+	err := registry.ResolveWith(map[string]interface{}{
+		"RequestID": "42",
+		"Overlaid":  "Temporary",
+	}, func(r *inject.Registry) error {
+		requestID, err := r.GetByName("RequestID", reflect.TypeOf(""))
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "42", requestID)
+
+		overlaid, err := r.GetByName("Overlaid", reflect.TypeOf(""))
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "Temporary", overlaid)
+		return nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByName("RequestID", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	overlaid, err := registry.GetByName("Overlaid", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Original", overlaid)
+}
+
+func TestServiceLocator_InjectFieldsInterfacePointer(t *testing.T) {
+	type InjectInto struct {
+		ByType *SimpleTestInterface `inject:""`
+		ByName *SimpleTestInterface `inject:"SimpleTestInterface"`
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("SimpleTestInterface", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	if assert.NotNil(t, injectInto.ByType) {
+		assert.Equal(t, "test1", (*injectInto.ByType).Test())
+	}
+	if assert.NotNil(t, injectInto.ByName) {
+		assert.Equal(t, "test1", (*injectInto.ByName).Test())
+	}
+}
+
+func TestServiceLocator_FillStruct(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type Context struct {
+		Service  *Injected
+		Named    *Injected `inject:"Named"`
+		Greeting string
+		Count    int
+		Data     interface{}
+		hidden   *Injected
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&Injected{name: "ByType"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("Named", &Injected{name: "ByName"})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+
+	context := Context{Count: 3}
+	if !assert.NoError(t, registry.FillStruct(&context)) {
+		return
+	}
+
+	if assert.NotNil(t, context.Service) {
+		assert.Equal(t, "ByType", context.Service.name)
+	}
+	if assert.NotNil(t, context.Named) {
+		assert.Equal(t, "ByName", context.Named.name)
+	}
+	assert.Equal(t, "Hello", context.Greeting)
+	assert.Equal(t, 3, context.Count)
+	assert.Nil(t, context.Data)
+	assert.Nil(t, context.hidden)
+}
+
+func TestServiceLocator_GetByTypeEmptyInterface(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+
+	// every binding implements interface{}, so it isn't resolved by its only implementation
+	_, err := registry.GetByType(reflect.TypeOf((*interface{})(nil)).Elem())
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_BindWithTypeDeclaredTypeMismatch(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithType(reflect.TypeOf(""), "Hello")) {
+		return
+	}
+
+	_, err := registry.GetByName("string", reflect.TypeOf(1))
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	assert.EqualError(t, err, `invalid injection type: "string" is declared as string, requested as int`)
+}
+
+func TestServiceLocator_ResolveValue(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	value, err := registry.ResolveValue(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	results := value.MethodByName("Test").Call(nil)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "test1", results[0].String())
+	}
+}
+
+func TestServiceLocator_ResolveValueMissing(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	value, err := registry.ResolveValue(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.False(t, value.IsValid())
+}
+
+type OtherTestInterfaceImpl struct {
+}
+
+func (s *OtherTestInterfaceImpl) Test() string {
+	return "test2"
+}
+
+func TestServiceLocator_InjectFromImplementingBinding(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	var service SimpleTestInterface
+	if !assert.NoError(t, registry.Inject(&service)) {
+		return
+	}
+	assert.Equal(t, "test1", service.Test())
+}
+
+func TestServiceLocator_InjectFromAmbiguousBinding(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&SimpleTestInterfaceImpl{})) {
+		return
+	}
+	if !assert.NoError(t, registry.Bind(&OtherTestInterfaceImpl{})) {
+		return
+	}
+
+	var service SimpleTestInterface
+	err := registry.Inject(&service)
+	assert.ErrorIs(t, err, inject.ErrAmbiguousBinding)
+	assert.EqualError(t, err, "ambiguous binding: inject_test.SimpleTestInterface is implemented by "+
+		"*inject_test.OtherTestInterfaceImpl, *inject_test.SimpleTestInterfaceImpl")
+	assert.Nil(t, service)
+}
+
+func TestServiceLocator_SetTagKey(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type InjectInto struct {
+		ServiceByType *Injected `wire:""`
+		ServiceByName *Injected `wire:"ServiceByName"`
+		Ignored       *Injected `inject:""`
+	}
+
+	registry := inject.NewRegistry()
+	registry.SetTagKey("wire")
+	if !assert.NoError(t, registry.Bind(&Injected{name: "ServiceByType"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("ServiceByName", &Injected{name: "ServiceByName"})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	if assert.NotNil(t, injectInto.ServiceByType) {
+		assert.Equal(t, "ServiceByType", injectInto.ServiceByType.name)
+	}
+	if assert.NotNil(t, injectInto.ServiceByName) {
+		assert.Equal(t, "ServiceByName", injectInto.ServiceByName.name)
+	}
+	assert.Nil(t, injectInto.Ignored)
+}
+
+type AfterInjectTarget struct {
+	Greeting string `inject:"greeting"`
+	called   bool
+}
+
+func (a *AfterInjectTarget) AfterInject() error {
+	a.called = true
+	if a.Greeting == "" {
+		return errors.New("greeting is required")
+	}
+	return nil
+}
+
+func TestServiceLocator_InjectFieldsAfterInject(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	target := AfterInjectTarget{}
+	if !assert.NoError(t, registry.InjectFields(&target)) {
+		return
+	}
+	assert.True(t, target.called)
+	assert.Equal(t, "Hello", target.Greeting)
+}
+
+func TestServiceLocator_InjectFieldsAfterInjectError(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "")) {
+		return
+	}
+	target := AfterInjectTarget{}
+	assert.EqualError(t, registry.InjectFields(&target), "greeting is required")
+	assert.True(t, target.called)
+}
+
+func TestServiceLocator_InjectFieldsNotSettable(t *testing.T) {
+	type Injected struct {
+		name string
+	}
+
+	type InjectInto struct {
+		service *Injected `inject:""`
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(&Injected{name: "ServiceByType"})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	err := registry.InjectFields(&injectInto)
+	assert.ErrorIs(t, err, inject.ErrFieldNotSettable)
+	assert.EqualError(t, err, "field is not settable: service")
+	assert.Nil(t, injectInto.service)
+}
+
+type MultiInterfaceImpl struct {
+	written []string
+}
+
+func (m *MultiInterfaceImpl) Test() string {
+	return "multi"
+}
+
+func (m *MultiInterfaceImpl) Write(p []byte) (int, error) {
+	m.written = append(m.written, string(p))
+	return len(p), nil
+}
+
+func TestServiceLocator_BindImplemented(t *testing.T) {
+	registry := inject.NewRegistry()
+	impl := &MultiInterfaceImpl{}
+	err := registry.BindImplemented(impl,
+		reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(),
+		reflect.TypeOf((*io.Writer)(nil)).Elem())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var service SimpleTestInterface
+	var writer io.Writer
+	var concrete *MultiInterfaceImpl
+	if !assert.NoError(t, registry.Inject(&service, &writer, &concrete)) {
+		return
+	}
+
+	assert.Same(t, impl, service)
+	assert.Same(t, impl, writer)
+	assert.Same(t, impl, concrete)
+}
+
+func TestServiceLocator_BindImplementedNotImplemented(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindImplemented(&SimpleTestInterfaceImpl{},
+		reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(),
+		reflect.TypeOf((*io.Writer)(nil)).Elem())
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+
+	_, err = registry.GetByType(reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_InjectFromImplementingSharedBinding(t *testing.T) {
+	registry := inject.NewRegistry()
+	impl := &MultiInterfaceImpl{}
+	if !assert.NoError(t, registry.BindImplemented(impl, reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())) {
+		return
+	}
+
+	var writer io.Writer
+	if !assert.NoError(t, registry.Inject(&writer)) {
+		return
+	}
+	assert.Same(t, impl, writer)
+}
+
+func TestServiceLocator_InjectFieldsEmbeddedInterface(t *testing.T) {
+	type InjectInto struct {
+		SimpleTestInterface `inject:""`
+	}
+
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(), &SimpleTestInterfaceImpl{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	if assert.NotNil(t, injectInto.SimpleTestInterface) {
+		// the embedded implementation promotes its methods
+		assert.Equal(t, "test1", injectInto.Test())
+	}
+}
+
+func TestServiceLocator_SetFieldSelector(t *testing.T) {
+	type MailService struct {
+		name string
+	}
+
+	type InjectInto struct {
+		MailService    *MailService
+		BackupService  *MailService
+		MailServiceURL string
+	}
+
+	registry := inject.NewRegistry()
+	registry.SetFieldSelector(func(field reflect.StructField) (string, bool) {
+		if !strings.HasSuffix(field.Name, "Service") {
+			return "", false
+		}
+		if field.Name == "BackupService" {
+			return "backup", true
+		}
+		return "", true
+	})
+	if !assert.NoError(t, registry.Bind(&MailService{name: "primary"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("backup", &MailService{name: "backup"})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+
+	if assert.NotNil(t, injectInto.MailService) {
+		assert.Equal(t, "primary", injectInto.MailService.name)
+	}
+	if assert.NotNil(t, injectInto.BackupService) {
+		assert.Equal(t, "backup", injectInto.BackupService.name)
+	}
+	assert.Empty(t, injectInto.MailServiceURL)
+}
+
+func TestServiceLocator_BindSlice(t *testing.T) {
+	type InjectInto struct {
+		Handlers []SimpleTestInterface `inject:""`
+		Named    []SimpleTestInterface `inject:"fallbacks"`
+	}
+
+	handlers := []SimpleTestInterface{&SimpleTestInterfaceImpl{}, &OtherTestInterfaceImpl{}}
+	fallbacks := []SimpleTestInterface{&OtherTestInterfaceImpl{}}
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(handlers)) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("fallbacks", fallbacks)) {
+		return
+	}
+
+	result, err := registry.GetByType(reflect.TypeOf(handlers))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, handlers, result)
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	assert.Equal(t, handlers, injectInto.Handlers)
+	assert.Equal(t, fallbacks, injectInto.Named)
+
+	// a slice is a binding on its own, it doesn't take part in resolving its element type
+	_, err = registry.GetByType(reflect.TypeOf((*SimpleTestInterface)(nil)).Elem())
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_BindWithNameAndType(t *testing.T) {
+	ifaceType := reflect.TypeOf((*SimpleTestInterface)(nil)).Elem()
+	service := &SimpleTestInterfaceImpl{}
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithNameAndType("x", ifaceType, service)) {
+		return
+	}
+
+	byName, err := registry.GetByName("x", ifaceType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	byType, err := registry.GetByType(ifaceType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, service, byName)
+	assert.Same(t, service, byType)
+}
+
+func TestServiceLocator_BindWithNameAndTypeNotAssignable(t *testing.T) {
+	ifaceType := reflect.TypeOf((*SimpleTestInterface)(nil)).Elem()
+	registry := inject.NewRegistry()
+
+	err := registry.BindWithNameAndType("x", ifaceType, "not a service")
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	_, err = registry.GetByName("x", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_InjectFieldsAutoPointer(t *testing.T) {
+	type Config struct {
+		URL string
+	}
+	type InjectInto struct {
+		Config *Config `inject:""`
+		Backup *Config `inject:"backup"`
+	}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind(Config{URL: "primary"})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("backup", Config{URL: "backup"})) {
+		return
+	}
+
+	injectInto := InjectInto{}
+	assert.ErrorIs(t, registry.InjectFields(&injectInto), inject.ErrEntryNotFound)
+
+	registry.AutoPointer(true)
+	injectInto = InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	if assert.NotNil(t, injectInto.Config) {
+		assert.Equal(t, "primary", injectInto.Config.URL)
+	}
+	if assert.NotNil(t, injectInto.Backup) {
+		assert.Equal(t, "backup", injectInto.Backup.URL)
+	}
+}
+
+func TestServiceLocator_BindWithValidator(t *testing.T) {
+	type Config struct {
+		stale bool
+	}
+
+	config := &Config{}
+	registry := inject.NewRegistry()
+	err := registry.BindWithValidator("config", config, func(value interface{}) error {
+		if value.(*Config).stale {
+			return errors.New("stale config")
+		}
+		return nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := registry.GetByName("config", reflect.TypeOf(config))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, config, result)
+
+	config.stale = true
+	_, err = registry.GetByName("config", reflect.TypeOf(config))
+	assert.EqualError(t, err, `validating "config": stale config`)
+}
+
+func TestServiceLocator_BindWithNameTyped(t *testing.T) {
+	ifaceType := reflect.TypeOf((*SimpleTestInterface)(nil)).Elem()
+	service := &SimpleTestInterfaceImpl{}
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithNameTyped("service", ifaceType, service)) {
+		return
+	}
+
+	result, err := registry.GetByName("service", ifaceType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, service, result)
+
+	_, err = registry.GetByName("service", reflect.TypeOf(service))
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	assert.EqualError(t, err, `invalid injection type: "service" is declared as inject_test.SimpleTestInterface, `+
+		`requested as *inject_test.SimpleTestInterfaceImpl`)
+}
+
+func TestServiceLocator_BindWithNameTypedNotAssignable(t *testing.T) {
+	ifaceType := reflect.TypeOf((*SimpleTestInterface)(nil)).Elem()
+	registry := inject.NewRegistry()
+
+	err := registry.BindWithNameTyped("service", ifaceType, "not a service")
+	assert.ErrorIs(t, err, inject.ErrInvalidInjectionType)
+	_, err = registry.GetByName("service", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
+
+func TestServiceLocator_InjectFieldsOptionalAndDefault(t *testing.T) {
+	type Config struct {
+		URL string
+	}
+	type InjectInto struct {
+		Optional *Config           `inject:",optional"`
+		Default  *Config           `inject:",default"`
+		Named    *Config           `inject:"config,default"`
+		Settings map[string]string `inject:",default"`
+		Present  string            `inject:",default"`
+	}
+
+	registry := inject.NewRegistry()
+	registry.MustBind("Hello")
+
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	assert.Nil(t, injectInto.Optional)
+	assert.Equal(t, &Config{}, injectInto.Default)
+	assert.Equal(t, &Config{}, injectInto.Named)
+	assert.NotNil(t, injectInto.Settings)
+	assert.Empty(t, injectInto.Settings)
+	assert.Equal(t, "Hello", injectInto.Present)
+}
+
+func TestServiceLocator_InjectFieldsDefaultUnexported(t *testing.T) {
+	type Cache struct {
+		entries map[string]string
+	}
+	type InjectInto struct {
+		cache *Cache `inject:",default"`
+	}
+
+	registry := inject.NewRegistry()
+	injectInto := InjectInto{}
+	err := registry.InjectFields(&injectInto)
+	assert.ErrorIs(t, err, inject.ErrFieldNotSettable)
+	assert.EqualError(t, err, "field is not settable: cache")
+	assert.Nil(t, injectInto.cache)
+}
+
+func TestServiceLocator_InjectFromBestEffort(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind("Hello")
+	registry.MustBind(&SimpleTestInterfaceImpl{})
+
+	var greeting string
+	var count int
+	var service *SimpleTestInterfaceImpl
+	err := registry.InjectFromBestEffort(nil, &greeting, &count, &service)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.EqualError(t, err, `target 1 of type *int: object not found: "int"`)
+
+	assert.Equal(t, "Hello", greeting)
+	assert.Equal(t, 0, count)
+	assert.NotNil(t, service)
+}
+
+type TripleInterfaceImpl struct {
+	MultiInterfaceImpl
+	closed bool
+}
+
+func (m *TripleInterfaceImpl) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestServiceLocator_GetByTypeMultipleInterfaces(t *testing.T) {
+	impl := &TripleInterfaceImpl{}
+	registry := inject.NewRegistry()
+	registry.MustBind(impl)
+
+	for _, iface := range []reflect.Type{
+		reflect.TypeOf((*SimpleTestInterface)(nil)).Elem(),
+		reflect.TypeOf((*io.Writer)(nil)).Elem(),
+		reflect.TypeOf((*io.Closer)(nil)).Elem(),
+	} {
+		result, err := registry.GetByType(iface)
+		if !assert.NoError(t, err, iface.String()) {
+			return
+		}
+		assert.Same(t, impl, result, iface.String())
+	}
+
+	type InjectInto struct {
+		Service SimpleTestInterface `inject:""`
+		Writer  io.Writer           `inject:""`
+		Closer  io.Closer           `inject:""`
+	}
+	injectInto := InjectInto{}
+	if !assert.NoError(t, registry.InjectFields(&injectInto)) {
+		return
+	}
+	assert.Same(t, impl, injectInto.Service)
+	assert.Same(t, impl, injectInto.Writer)
+	assert.Same(t, impl, injectInto.Closer)
+}
+
+func TestServiceLocator_InjectFromInvalidTargets(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.Bind("Hello")) {
+		return
+	}
+
+	var nilPointer *string
+	var pointer unsafe.Pointer
+	for _, test := range []struct {
+		target interface{}
+		err    error
+		msg    string
+	}{
+		{nil, inject.ErrNilTarget, "invalid injection point: target is nil"},
+		{"Hello", inject.ErrTargetNotAPointer, "invalid injection point: target is not a pointer: string"},
+		{nilPointer, inject.ErrNilTarget, "invalid injection point: target is nil: *string"},
+		{&pointer, inject.ErrUnsupportedTarget, "invalid injection point: unsupported target kind: *unsafe.Pointer"},
+	} {
+		err := registry.InjectFrom(nil, test.target)
+		assert.ErrorIs(t, err, test.err)
+		assert.ErrorIs(t, err, inject.ErrInvalidInjectionPoint)
+		assert.EqualError(t, err, test.msg)
+	}
+}
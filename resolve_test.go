@@ -0,0 +1,110 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	assert.Equal(t, reflect.TypeOf(""), inject.TypeOf[string]())
+	assert.Equal(t, reflect.TypeOf(&SimpleTestInterfaceImpl{}), inject.TypeOf[*SimpleTestInterfaceImpl]())
+	assert.Equal(t, reflect.Interface, inject.TypeOf[io.Writer]().Kind())
+	assert.Equal(t, "io.Writer", inject.TypeOf[io.Writer]().String())
+}
+
+func TestResolve_Concrete(t *testing.T) {
+	registry := inject.NewRegistry()
+	service := &SimpleTestInterfaceImpl{}
+	registry.MustBind(service)
+
+	result, err := inject.Resolve[*SimpleTestInterfaceImpl](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, service, result)
+}
+
+func TestResolve_ConcreteBoundByName(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("names", []string{"a", "b"})) {
+		return
+	}
+
+	result, err := inject.Resolve[[]string](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"a", "b"}, result)
+}
+
+func TestResolve_Interface(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("service", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	result, err := inject.Resolve[SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "test1", result.Test())
+}
+
+func TestResolve_InterfaceBoundAsInterface(t *testing.T) {
+	registry := inject.NewRegistry()
+	err := registry.BindWithType(inject.TypeOf[SimpleTestInterface](), &StrategyHandler{name: "declared"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("other", &SimpleTestInterfaceImpl{})) {
+		return
+	}
+
+	result, err := inject.Resolve[SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "declared", result.Test())
+}
+
+func TestResolve_Func(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greet", func() string { return "Hello" })) {
+		return
+	}
+
+	result, err := inject.Resolve[func() string](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", result())
+}
+
+func TestResolve_Ambiguous(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("first", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("second", "Hi")) {
+		return
+	}
+
+	_, err := inject.Resolve[string](registry)
+	assert.ErrorIs(t, err, inject.ErrAmbiguousBinding)
+	assert.EqualError(t, err, "ambiguous binding: string is bound as first, second")
+}
+
+func TestResolve_Missing(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	result, err := inject.Resolve[io.Writer](registry)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.Nil(t, result)
+
+	count, err := inject.Resolve[int](registry)
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,158 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// scopedProducer is the source of bindings bound with BindScoped.
+// Outside of a matching Scope it refuses to produce.
+type scopedProducer struct {
+	scope    string
+	producer Producer
+}
+
+func (p scopedProducer) Produce(source interface{}, target reflect.Type) (interface{}, error) {
+	return nil, fmt.Errorf("%w: binding is scoped to %q", ErrNotInScope, p.scope)
+}
+
+// BindScoped binds producer under name for the given scope, e.g. "request".
+// Each Scope entered for it produces its own instance, which is disposed when the scope is exited.
+// Resolving the binding from the registry itself fails with ErrNotInScope.
+func (r *Registry) BindScoped(scope string, name string, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    scopedProducer{scope: scope, producer: producer},
+	})
+}
+
+// Scope is an active instance of a scope, e.g. a single request. It resolves the bindings of its scope
+// to one instance per Scope and everything else from the registry.
+type Scope struct {
+	registry  *Registry
+	scope     string
+	mu        sync.Mutex
+	instances map[string]interface{}
+	producing map[string]chan struct{}
+	created   []string
+	exited    bool
+}
+
+type scopeKey struct{}
+
+func scopeFrom(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// EnterScope starts a new instance of scope, e.g. for a request. It must be exited with Exit once done.
+func (r *Registry) EnterScope(scope string) *Scope {
+	return &Scope{
+		registry:  r,
+		scope:     scope,
+		instances: make(map[string]interface{}),
+		producing: make(map[string]chan struct{}),
+	}
+}
+
+// GetByName resolves the binding registered under name, producing it once per Scope if it is bound to the scope.
+// The bindings it depends on, e.g. as constructor parameters, are resolved within the Scope as well.
+func (s *Scope) GetByName(name string, expectedType reflect.Type) (interface{}, error) {
+	return s.registry.getByName(name, expectedType, resolution{scope: s})
+}
+
+// GetByType resolves the binding for expectedType like GetByName.
+func (s *Scope) GetByType(expectedType reflect.Type) (interface{}, error) {
+	return s.registry.getByType(expectedType, resolution{scope: s})
+}
+
+// InjectFields works like Registry.InjectFields, but resolves the bindings of the scope to the instances of this Scope.
+func (s *Scope) InjectFields(target interface{}) error {
+	r := s.registry
+	return r.protect(func() error {
+		target := r.interceptTarget(target)
+		return r.injectFields(target, r.selectorFor(SourceType(target)), false, r.skipNonZero, s)
+	})
+}
+
+// Invoke works like Registry.Invoke, but resolves the bindings of the scope to the instances of this Scope.
+func (s *Scope) Invoke(fn interface{}) ([]interface{}, error) {
+	return s.registry.invoke(fn, resolution{scope: s})
+}
+
+// instance returns the instance of the scoped binding name, producing it with r on first use.
+// Concurrent resolutions wait for the one producing it, so producer runs once per Scope, unless production fails.
+func (s *Scope) instance(r *Registry, name string, producer Producer, expectedType reflect.Type, res resolution) (interface{}, error) {
+	s.mu.Lock()
+	for {
+		if s.exited {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("%w: scope %q was exited", ErrNotInScope, s.scope)
+		}
+		if value, exists := s.instances[name]; exists {
+			s.mu.Unlock()
+			return value, nil
+		}
+		producing, inFlight := s.producing[name]
+		if !inFlight {
+			break
+		}
+		s.mu.Unlock()
+		<-producing
+		s.mu.Lock()
+	}
+	producing := make(chan struct{})
+	s.producing[name] = producing
+	s.mu.Unlock()
+	defer func() {
+		// release the waiting resolutions, even if the producer panics
+		s.mu.Lock()
+		delete(s.producing, name)
+		s.mu.Unlock()
+		close(producing)
+	}()
+
+	value, err := r.invokeProducer(producer, res, expectedType)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.exited {
+		// Exit can't dispose an instance produced after it
+		if disposable, ok := value.(Disposable); ok {
+			_ = disposable.Dispose()
+		}
+		return nil, fmt.Errorf("%w: scope %q was exited", ErrNotInScope, s.scope)
+	}
+	s.instances[name] = value
+	s.created = append(s.created, name)
+	return value, nil
+}
+
+// Exit ends the scope and calls Dispose on its instances implementing Disposable, in reverse order of their creation.
+// The errors of all failures are returned joined. Resolving scoped bindings from an exited Scope fails with ErrNotInScope.
+func (s *Scope) Exit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exited = true
+
+	var errs []error
+	for i := len(s.created) - 1; i >= 0; i-- {
+		name := s.created[i]
+		if disposable, ok := s.instances[name].(Disposable); ok {
+			if err := disposable.Dispose(); err != nil {
+				errs = append(errs, fmt.Errorf("disposing %q: %w", name, err))
+			}
+		}
+	}
+	s.instances, s.created = nil, nil
+	return errors.Join(errs...)
+}
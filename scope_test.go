@@ -0,0 +1,187 @@
+package inject_test
+
+import (
+	"fmt"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_BindScoped(t *testing.T) {
+	var disposed []string
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindScoped("request", "session", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &DisposableService{name: fmt.Sprintf("session %d", produced), disposed: &disposed}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	first := registry.EnterScope("request")
+	second := registry.EnterScope("request")
+	sessionType := reflect.TypeOf(&DisposableService{})
+
+	firstSession, err := first.GetByName("session", sessionType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	firstAgain, err := first.GetByName("session", sessionType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	secondSession, err := second.GetByName("session", sessionType)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, firstSession, firstAgain)
+	assert.NotSame(t, firstSession, secondSession)
+	assert.Equal(t, 2, produced)
+
+	greeting, err := first.GetByName("greeting", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", greeting)
+
+	if !assert.NoError(t, first.Exit()) {
+		return
+	}
+	assert.Equal(t, []string{"session 1"}, disposed)
+	_, err = first.GetByName("session", sessionType)
+	assert.ErrorIs(t, err, inject.ErrNotInScope)
+
+	if !assert.NoError(t, second.Exit()) {
+		return
+	}
+	assert.Equal(t, []string{"session 1", "session 2"}, disposed)
+}
+
+func TestServiceLocator_BindScopedOutOfScope(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindScoped("request", "session", constantProducer("session"))) {
+		return
+	}
+
+	_, err := registry.GetByName("session", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrNotInScope)
+
+	_, err = registry.EnterScope("job").GetByName("session", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrNotInScope)
+}
+
+func TestServiceLocator_ScopeInjectFields(t *testing.T) {
+	type Session struct {
+		id int
+	}
+	type Handler struct {
+		Session  *Session `inject:""`
+		Greeting string   `inject:"greeting"`
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	err := registry.BindScoped("request", "*inject_test.Session", inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced++
+		return &Session{id: produced}, nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	scope := registry.EnterScope("request")
+	handler := Handler{}
+	if !assert.NoError(t, scope.InjectFields(&handler)) {
+		return
+	}
+	if assert.NotNil(t, handler.Session) {
+		assert.Equal(t, 1, handler.Session.id)
+	}
+	assert.Equal(t, "Hello", handler.Greeting)
+
+	results, err := scope.Invoke(func(session *Session) *Session {
+		return session
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, handler.Session, results[0])
+	assert.Equal(t, 1, produced)
+
+	// the registry itself still refuses scoped bindings
+	assert.ErrorIs(t, registry.InjectFields(&Handler{}), inject.ErrNotInScope)
+}
+
+func TestServiceLocator_ProvideScoped(t *testing.T) {
+	type Session struct {
+		id int
+	}
+	type Repository struct {
+		session *Session
+	}
+	type Cache struct {
+		session *Session
+	}
+
+	produced := 0
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, inject.ProvideScoped[*Session](registry, "request", func() *Session {
+		produced++
+		return &Session{id: produced}
+	})) {
+		return
+	}
+	if !assert.NoError(t, inject.ProvideScoped[*Repository](registry, "request", func(session *Session) *Repository {
+		return &Repository{session: session}
+	})) {
+		return
+	}
+	if !assert.NoError(t, inject.Provide[*Cache](registry, func(session *Session) *Cache {
+		return &Cache{session: session}
+	})) {
+		return
+	}
+
+	for i := 1; i <= 2; i++ {
+		scope := registry.EnterScope("request")
+		repository, err := scope.GetByType(reflect.TypeOf(&Repository{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		session, err := scope.GetByType(reflect.TypeOf(&Session{}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Same(t, session, repository.(*Repository).session)
+		assert.Equal(t, i, session.(*Session).id)
+		assert.NoError(t, scope.Exit())
+	}
+
+	// a singleton outlives the scope, so it can't capture a scoped instance
+	_, err := registry.EnterScope("request").GetByType(reflect.TypeOf(&Cache{}))
+	assert.ErrorIs(t, err, inject.ErrNotInScope)
+}
+
+func TestServiceLocator_ProvideScopedCircular(t *testing.T) {
+	type A struct{}
+	type B struct{}
+
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, inject.ProvideScoped[*A](registry, "request", func(*B) *A { return &A{} })) {
+		return
+	}
+	if !assert.NoError(t, inject.ProvideScoped[*B](registry, "request", func(*A) *B { return &B{} })) {
+		return
+	}
+
+	_, err := registry.EnterScope("request").GetByType(reflect.TypeOf(&A{}))
+	assert.ErrorIs(t, err, inject.ErrCircularDependency)
+}
@@ -0,0 +1,68 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// InjectSetters calls all setter methods of target with the bindings resolved by their parameter type.
+// Setters are exported methods named Set<Name>, taking a single parameter and returning nothing or an error.
+// Setters without a matching binding are skipped, like fields with FillStruct.
+func (r *Registry) InjectSetters(target interface{}) error {
+	return r.protect(func() error {
+		return r.injectSetters(target)
+	})
+}
+
+func (r *Registry) injectSetters(target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if !targetValue.IsValid() || targetValue.Kind() != reflect.Ptr {
+		return ErrInvalidInjectionPoint
+	}
+
+	targetType := targetValue.Type()
+	for i := 0; i < targetType.NumMethod(); i++ {
+		method := targetType.Method(i)
+		if !isSetter(method) {
+			continue
+		}
+
+		paramType := method.Type.In(1)
+		value, err := r.resolveType(paramType, "", resolution{source: target})
+		if errors.Is(err, ErrEntryNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", method.Name, err)
+		}
+		if !reflect.TypeOf(value).AssignableTo(paramType) {
+			return fmt.Errorf("%w: %s cannot be called with %T", ErrInvalidInjectionType, method.Name, value)
+		}
+
+		results := targetValue.Method(i).Call([]reflect.Value{reflect.ValueOf(value)})
+		if len(results) == 1 && !results[0].IsNil() {
+			return results[0].Interface().(error)
+		}
+	}
+	return nil
+}
+
+// isSetter reports whether method looks like Set<Name>(value) or Set<Name>(value) error.
+// The method type includes the receiver as first parameter.
+func isSetter(method reflect.Method) bool {
+	name, ok := strings.CutPrefix(method.Name, "Set")
+	if !ok || name == "" || !unicode.IsUpper(rune(name[0])) || method.Type.NumIn() != 2 || method.Type.IsVariadic() {
+		return false
+	}
+	switch method.Type.NumOut() {
+	case 0:
+		return true
+	case 1:
+		return method.Type.Out(0) == errorType
+	default:
+		return false
+	}
+}
@@ -0,0 +1,64 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type SetterTarget struct {
+	service SimpleTestInterface
+	name    string
+	missing *OtherTestInterfaceImpl
+	settled bool
+}
+
+func (s *SetterTarget) SetService(service SimpleTestInterface) {
+	s.service = service
+}
+
+func (s *SetterTarget) SetName(name string) error {
+	if name == "" {
+		return errors.New("empty name")
+	}
+	s.name = name
+	return nil
+}
+
+func (s *SetterTarget) SetMissing(missing *OtherTestInterfaceImpl) {
+	s.missing = missing
+}
+
+func (s *SetterTarget) Settle(settled bool) {
+	s.settled = settled
+}
+
+func TestServiceLocator_InjectSetters(t *testing.T) {
+	service := &SimpleTestInterfaceImpl{}
+	registry := inject.NewRegistry()
+	registry.MustBind(service)
+	registry.MustBind("Hello")
+	registry.MustBind(true)
+
+	target := SetterTarget{}
+	if !assert.NoError(t, registry.InjectSetters(&target)) {
+		return
+	}
+	assert.Same(t, service, target.service)
+	assert.Equal(t, "Hello", target.name)
+	assert.Nil(t, target.missing)
+	assert.False(t, target.settled)
+}
+
+func TestServiceLocator_InjectSettersError(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind("")
+
+	assert.EqualError(t, registry.InjectSetters(&SetterTarget{}), "empty name")
+}
+
+func TestServiceLocator_InjectSettersInvalidTarget(t *testing.T) {
+	registry := inject.NewRegistry()
+	assert.Equal(t, inject.ErrInvalidInjectionPoint, registry.InjectSetters(SetterTarget{}))
+}
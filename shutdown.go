@@ -0,0 +1,54 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Disposable is implemented by bindings holding resources, which need to be released on Shutdown.
+type Disposable interface {
+	Dispose() error
+}
+
+// Shutdown calls Dispose on all bindings implementing Disposable and the cleanups of bindings bound with BindFactory.
+// Bindings are disposed by ascending shutdown order (see BindWithShutdownOrder) and in reverse order of their
+// initialization by Populate within the same shutdown order.
+// All bindings are disposed, even if disposing one of them fails. The errors of all failures are returned joined.
+func (r *Registry) Shutdown() error {
+	entries := r.snapshot()
+	initOrder := r.initOrder(entries)
+	order := make([]string, len(initOrder))
+	for i, name := range initOrder {
+		order[len(order)-1-i] = name
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return entries[order[i]].shutdownOrder < entries[order[j]].shutdownOrder
+	})
+
+	var errs []error
+	for _, name := range order {
+		if factory, ok := entries[name].source.(*factoryProducer); ok {
+			factory.cleanup()
+			continue
+		}
+		disposable, ok := entries[name].source.(Disposable)
+		if !ok {
+			continue
+		}
+		if err := disposable.Dispose(); err != nil {
+			errs = append(errs, fmt.Errorf("disposing %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BindWithShutdownOrder binds value under name like BindWithName and disposes it by ascending order on Shutdown.
+// Bindings without an explicit shutdown order have order 0, bindings of the same order are disposed in reverse init order.
+func (r *Registry) BindWithShutdownOrder(name string, value interface{}, order int) error {
+	return r.bind(name, &registryEntry{
+		populated:     false,
+		source:        value,
+		shutdownOrder: order,
+	})
+}
@@ -0,0 +1,121 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type DisposableService struct {
+	name     string
+	err      error
+	disposed *[]string
+}
+
+func (d *DisposableService) Dispose() error {
+	*d.disposed = append(*d.disposed, d.name)
+	return d.err
+}
+
+func TestServiceLocator_Shutdown(t *testing.T) {
+	var disposed []string
+	registry := inject.NewRegistry()
+	err := registry.BindWithDeps("api", &DisposableService{name: "api", disposed: &disposed}, "database")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("database", &DisposableService{name: "database", disposed: &disposed})) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Shutdown()) {
+		return
+	}
+	assert.Equal(t, []string{"api", "database"}, disposed)
+}
+
+func TestServiceLocator_ShutdownJoinsErrors(t *testing.T) {
+	errAPI := errors.New("api still busy")
+	errDatabase := errors.New("connection lost")
+
+	var disposed []string
+	registry := inject.NewRegistry()
+	err := registry.BindWithDeps("api", &DisposableService{name: "api", err: errAPI, disposed: &disposed}, "database")
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = registry.BindWithName("database", &DisposableService{name: "database", err: errDatabase, disposed: &disposed})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = registry.Shutdown()
+	assert.ErrorIs(t, err, errAPI)
+	assert.ErrorIs(t, err, errDatabase)
+	assert.Equal(t, []string{"api", "database"}, disposed)
+}
+
+func TestServiceLocator_BindWithShutdownOrder(t *testing.T) {
+	var disposed []string
+	registry := inject.NewRegistry()
+	err := registry.BindWithShutdownOrder("metrics", &DisposableService{name: "metrics", disposed: &disposed}, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("cache", &DisposableService{name: "cache", disposed: &disposed})) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("database", &DisposableService{name: "database", disposed: &disposed})) {
+		return
+	}
+
+	// initialized in insertion order, metrics is disposed last regardless
+	if !assert.NoError(t, registry.Shutdown()) {
+		return
+	}
+	assert.Equal(t, []string{"database", "cache", "metrics"}, disposed)
+}
+
+func TestServiceLocator_BindFactory(t *testing.T) {
+	var cleaned []string
+	registry := inject.NewRegistry()
+	err := registry.BindFactory("connection", func() (interface{}, func(), error) {
+		return &SimpleTestInterfaceImpl{}, func() { cleaned = append(cleaned, "connection") }, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, err := registry.GetByName("connection", reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := registry.GetByName("connection", reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Same(t, first, second)
+	assert.Empty(t, cleaned)
+
+	if !assert.NoError(t, registry.Shutdown()) {
+		return
+	}
+	assert.Equal(t, []string{"connection"}, cleaned)
+}
+
+func TestServiceLocator_BindFactoryError(t *testing.T) {
+	errConnect := errors.New("connection refused")
+	registry := inject.NewRegistry()
+	err := registry.BindFactory("connection", func() (interface{}, func(), error) {
+		return nil, nil, errConnect
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = registry.GetByName("connection", reflect.TypeOf(&SimpleTestInterfaceImpl{}))
+	assert.ErrorIs(t, err, errConnect)
+	assert.NoError(t, registry.Shutdown())
+}
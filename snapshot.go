@@ -0,0 +1,25 @@
+package inject
+
+// Snapshot is the set of bindings of a registry at some point in time, see Registry.Snapshot.
+type Snapshot struct {
+	entries map[string]*registryEntry
+}
+
+// Snapshot captures the current bindings, so they can be put back with Restore, e.g. after a test stubbed some of them.
+// Singletons produced in the meantime stay cached, the bindings themselves are not copied.
+func (r *Registry) Snapshot() *Snapshot {
+	return &Snapshot{entries: r.snapshot()}
+}
+
+// Restore replaces the bindings of the registry with those captured by snapshot.
+// Bindings added since are dropped, replaced ones are bound again.
+func (r *Registry) Restore(snapshot *Snapshot) {
+	entries := make(map[string]*registryEntry, len(snapshot.entries))
+	for name, entry := range snapshot.entries {
+		entries[name] = entry
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = entries
+}
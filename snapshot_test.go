@@ -0,0 +1,33 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_SnapshotRestore(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+
+	snapshot := registry.Snapshot()
+	if !assert.NoError(t, registry.BindWithName("greeting", "Stub")) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("farewell", "Bye")) {
+		return
+	}
+
+	registry.Restore(snapshot)
+	greeting, err := registry.GetByName("greeting", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Hello", greeting)
+
+	_, err = registry.GetByName("farewell", reflect.TypeOf(""))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+}
@@ -0,0 +1,79 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BindStrategy binds value as strategy for key, e.g. the handler of a message type.
+// All strategies of the same key and value type are resolved at once with GetStrategies.
+// The strategy is bound under a name composed of the types and the key, like "strategy[string]main.Handler:users".
+// Distinct keys printing alike get a numbered suffix, like "strategy[main.Route]main.Handler:/users#2".
+func BindStrategy[K comparable, V any](r *Registry, key K, value V) error {
+	valueType := TypeOf[V]()
+	entry := &registryEntry{
+		populated:    false,
+		source:       value,
+		declaredType: valueType,
+		strategyKey:  key,
+	}
+
+	r.mu.Lock()
+	name := r.strategyName(strategyPrefix[K, V](), key)
+	admitted, err := r.admits(name)
+	var bound []boundEntry
+	if admitted {
+		bound = append(bound, r.putBound(name, entry))
+	}
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return r.injectBound(bound, value)
+}
+
+// GetStrategies resolves all strategies bound with BindStrategy for key type K and value type V, keyed by their key.
+func GetStrategies[K comparable, V any](r *Registry) (map[K]V, error) {
+	prefix := strategyPrefix[K, V]()
+	valueType := TypeOf[V]()
+	entries := r.snapshot()
+	strategies := make(map[K]V)
+	for _, name := range sortedKeys(entries) {
+		key, ok := entries[name].strategyKey.(K)
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		value, err := r.GetByName(name, valueType)
+		if err != nil {
+			return nil, fmt.Errorf("resolving strategy %q: %w", name, err)
+		}
+		typedValue, err := typed[V](value, name)
+		if err != nil {
+			return nil, err
+		}
+		strategies[key] = typedValue
+	}
+	return strategies, nil
+}
+
+func strategyPrefix[K comparable, V any]() string {
+	keyType := TypeOf[K]()
+	valueType := TypeOf[V]()
+	return fmt.Sprintf("strategy[%s]%s:", keyType, valueType)
+}
+
+// strategyName returns the name of the strategy for key, which is the name of the strategy bound for key already, if any.
+// Otherwise the printed key is suffixed with a number while it names the strategy of another key.
+// The caller must hold the lock.
+func (r *Registry) strategyName(prefix string, key interface{}) string {
+	name := prefix + fmt.Sprint(key)
+	candidate := name
+	for n := 2; ; n++ {
+		entry, exists := r.entries[candidate]
+		if !exists || entry.strategyKey == key {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s#%d", name, n)
+	}
+}
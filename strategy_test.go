@@ -0,0 +1,85 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type StrategyHandler struct {
+	name string
+}
+
+func (h *StrategyHandler) Test() string {
+	return h.name
+}
+
+func TestBindStrategy(t *testing.T) {
+	registry := inject.NewRegistry()
+	for _, name := range []string{"users", "orders"} {
+		if !assert.NoError(t, inject.BindStrategy[string, SimpleTestInterface](registry, name, &StrategyHandler{name: name})) {
+			return
+		}
+	}
+	// strategies of other key or value types are kept apart
+	if !assert.NoError(t, inject.BindStrategy[int, SimpleTestInterface](registry, 1, &StrategyHandler{name: "one"})) {
+		return
+	}
+	if !assert.NoError(t, inject.BindStrategy[string, string](registry, "users", "Hello")) {
+		return
+	}
+
+	strategies, err := inject.GetStrategies[string, SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, strategies, 2)
+	assert.Equal(t, "users", strategies["users"].Test())
+	assert.Equal(t, "orders", strategies["orders"].Test())
+
+	byInt, err := inject.GetStrategies[int, SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "one", byInt[1].Test())
+}
+
+type StrategyRoute struct {
+	method string
+	path   string
+}
+
+func (r StrategyRoute) String() string {
+	return r.path
+}
+
+func TestBindStrategy_KeysPrintingAlike(t *testing.T) {
+	registry := inject.NewRegistry()
+	get := StrategyRoute{method: "GET", path: "/users"}
+	post := StrategyRoute{method: "POST", path: "/users"}
+	for _, route := range []StrategyRoute{get, post} {
+		if !assert.NoError(t, inject.BindStrategy[StrategyRoute, string](registry, route, route.method)) {
+			return
+		}
+	}
+	// rebinding a key replaces its strategy
+	if !assert.NoError(t, inject.BindStrategy[StrategyRoute, string](registry, post, "PUT")) {
+		return
+	}
+
+	strategies, err := inject.GetStrategies[StrategyRoute, string](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[StrategyRoute]string{get: "GET", post: "PUT"}, strategies)
+}
+
+func TestGetStrategies_Empty(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	strategies, err := inject.GetStrategies[string, SimpleTestInterface](registry)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, strategies)
+}
@@ -0,0 +1,30 @@
+package inject
+
+import (
+	"strings"
+)
+
+// tagOptions are the comma separated options following the name of an inject tag.
+type tagOptions []string
+
+func (o tagOptions) has(option string) bool {
+	for _, candidate := range o {
+		if candidate == option {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTag splits an inject tag like `inject:"name,option"` into the binding name and its options.
+// An empty name injects by type. Supported options:
+//   - new: produces a fresh value, even if the binding is a cached singleton
+//   - byconsumer: resolves the binding named after the consuming struct type (e.g. "pkg.Service") first,
+//     falling back to the regular resolution if there is none
+//   - optional: leaves the field untouched, if there is no binding
+//   - default: sets the field to a zero instance, if there is no binding, e.g. a pointer to a zero struct
+//   - deep: injects the fields of the values of an injected map, which are pointers to structs
+func parseTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+	return parts[0], tagOptions(parts[1:])
+}
@@ -0,0 +1,64 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TraceResolution configures the registry to record the names of all bindings looked up by a resolution,
+// including nested resolutions of constructors. Failing resolutions return a *ResolutionError carrying the trace.
+func (r *Registry) TraceResolution(enabled bool) {
+	r.traceResolution = enabled
+}
+
+// ResolutionError is returned by failing resolutions, if TraceResolution is enabled.
+// It wraps the original error, so errors.Is and errors.As see through it.
+type ResolutionError struct {
+	// Trace lists the names of the bindings looked up, in the order they were attempted.
+	Trace []string
+	Err   error
+}
+
+func (e *ResolutionError) Error() string {
+	return fmt.Sprintf("%s (resolution trace: %s)", e.Err, strings.Join(e.Trace, " -> "))
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// resolutionTrace collects the names looked up by a resolution and the nested resolutions it triggers.
+type resolutionTrace struct {
+	names []string
+}
+
+type traceKey struct{}
+
+func traceFrom(ctx context.Context) *resolutionTrace {
+	trace, _ := ctx.Value(traceKey{}).(*resolutionTrace)
+	return trace
+}
+
+// traced starts a trace for res, if tracing is enabled and res isn't traced already as part of an outer resolution.
+// The returned function attaches the trace to the error of the resolution.
+func (r *Registry) traced(res resolution) (resolution, func(err error) error) {
+	if !r.traceResolution || res.trace != nil {
+		return res, func(err error) error { return err }
+	}
+
+	trace := &resolutionTrace{}
+	res.trace = trace
+	return res, func(err error) error {
+		if err == nil {
+			return nil
+		}
+		return &ResolutionError{Trace: trace.names, Err: err}
+	}
+}
+
+func (t *resolutionTrace) add(name string) {
+	if t != nil {
+		t.names = append(t.names, name)
+	}
+}
@@ -0,0 +1,47 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type TraceConfig struct{}
+
+type TraceRepository struct {
+	Config *TraceConfig
+}
+
+type TraceService struct {
+	Repository *TraceRepository `inject:""`
+}
+
+func TestServiceLocator_TraceResolution(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.TraceResolution(true)
+	if !assert.NoError(t, inject.Provide[*TraceRepository](registry, func(config *TraceConfig) *TraceRepository {
+		return &TraceRepository{Config: config}
+	})) {
+		return
+	}
+
+	err := registry.InjectFields(&TraceService{})
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	var resolutionErr *inject.ResolutionError
+	if assert.ErrorAs(t, err, &resolutionErr) {
+		assert.Equal(t, []string{"*inject_test.TraceRepository", "*inject_test.TraceConfig"}, resolutionErr.Trace)
+	}
+}
+
+func TestServiceLocator_TraceResolutionDisabled(t *testing.T) {
+	registry := inject.NewRegistry()
+
+	_, err := registry.GetByType(reflect.TypeOf(&TraceConfig{}))
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+
+	var resolutionErr *inject.ResolutionError
+	assert.False(t, errors.As(err, &resolutionErr))
+}
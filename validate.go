@@ -0,0 +1,57 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RegisterInjectable registers the struct type of prototype, which is injected later with InjectFields,
+// so Validate checks its annotated fields without an instance. prototype must be a struct or a pointer to a struct.
+func (r *Registry) RegisterInjectable(prototype interface{}) error {
+	structType := reflect.TypeOf(prototype)
+	if structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return ErrInvalidInjectionPoint
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.injectables = append(r.injectables, structType)
+	return nil
+}
+
+// Validate checks that the dependencies of all bindings and registered injectables are bound, without producing anything.
+// Dependencies are the same as for Plan, optional fields may be missing. All missing dependencies are reported at once.
+func (r *Registry) Validate() error {
+	var errs []error
+	entries := uniqueEntries(r.snapshot())
+	names := sortedKeys(entries)
+	for _, name := range names {
+		if err := r.validateDependencies(r.dependencies(entries[name])); err != nil {
+			errs = append(errs, fmt.Errorf("binding %q: %w", name, err))
+		}
+	}
+
+	r.mu.RLock()
+	injectables := append([]reflect.Type(nil), r.injectables...)
+	r.mu.RUnlock()
+	for _, structType := range injectables {
+		if err := r.validateDependencies(r.fieldDependencies(structType)); err != nil {
+			errs = append(errs, fmt.Errorf("injectable %s: %w", structType, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) validateDependencies(dependencies []dependency) error {
+	var errs []error
+	for _, dep := range dependencies {
+		if _, _, err := r.bindingName(dep); err != nil && !dep.optional {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package inject_test
+
+import (
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ValidatedRepository struct {
+}
+
+type ValidatedHandler struct {
+	Repository *ValidatedRepository `inject:""`
+	Cache      *PlanService         `inject:",optional"`
+	Greeting   string               `inject:"greeting"`
+}
+
+func TestServiceLocator_Validate(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&ValidatedRepository{})
+	if !assert.NoError(t, registry.BindWithName("greeting", "Hello")) {
+		return
+	}
+	if !assert.NoError(t, registry.RegisterInjectable(ValidatedHandler{})) {
+		return
+	}
+
+	assert.NoError(t, registry.Validate())
+}
+
+func TestServiceLocator_ValidateMissingDependency(t *testing.T) {
+	registry := inject.NewRegistry()
+	registry.MustBind(&ValidatedRepository{})
+	if !assert.NoError(t, registry.RegisterInjectable(&ValidatedHandler{})) {
+		return
+	}
+
+	err := registry.Validate()
+	assert.ErrorIs(t, err, inject.ErrEntryNotFound)
+	assert.EqualError(t, err, `injectable inject_test.ValidatedHandler: object not found: "greeting"`)
+}
+
+func TestServiceLocator_ValidateBinding(t *testing.T) {
+	registry := inject.NewRegistry()
+	if !assert.NoError(t, registry.BindWithDeps("api", "Hello", "database", "cache")) {
+		return
+	}
+
+	assert.EqualError(t, registry.Validate(), "binding \"api\": object not found: \"database\"\nobject not found: \"cache\"")
+}
+
+func TestServiceLocator_RegisterInjectableInvalid(t *testing.T) {
+	registry := inject.NewRegistry()
+	assert.Equal(t, inject.ErrInvalidInjectionPoint, registry.RegisterInjectable("Hello"))
+	assert.Equal(t, inject.ErrInvalidInjectionPoint, registry.RegisterInjectable(nil))
+}
@@ -0,0 +1,54 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Warm produces all singletons not produced yet, so the first real resolution doesn't pay for their construction.
+// All singletons are produced, even if producing one of them fails. The errors of all failures are returned joined.
+func (r *Registry) Warm() error {
+	return r.warm(func(entry *registryEntry) bool { return true })
+}
+
+// BindEager binds producer under name like BindSingletonWithName, but produces it at the end of Populate
+// instead of on its first resolution. Use Warm to produce all singletons at once instead.
+func (r *Registry) BindEager(name string, producer Producer) error {
+	if producer == nil {
+		return ErrInvalidProducer
+	}
+	return r.bind(name, &registryEntry{
+		populated: false,
+		source:    producer,
+		singleton: true,
+		eager:     true,
+	})
+}
+
+// warm produces the singletons selected by include, by name.
+func (r *Registry) warm(include func(entry *registryEntry) bool) error {
+	entries := r.snapshot()
+	names := sortedKeys(entries)
+
+	var errs []error
+	for _, name := range names {
+		entry := entries[name]
+		if !entry.singleton || !isProducer(entry.source) || !include(entry) {
+			continue
+		}
+		expectedType := entry.declaredType
+		if expectedType == nil {
+			expectedType = reflect.TypeOf((*interface{})(nil)).Elem()
+		}
+		if _, err := r.GetByName(name, expectedType); err != nil {
+			errs = append(errs, fmt.Errorf("warming %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// warmEager produces the singletons bound with BindEager.
+func (r *Registry) warmEager() error {
+	return r.warm(func(entry *registryEntry) bool { return entry.eager })
+}
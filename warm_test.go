@@ -0,0 +1,86 @@
+package inject_test
+
+import (
+	"errors"
+	"github.com/dreske/go-inject"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestServiceLocator_Warm(t *testing.T) {
+	registry := inject.NewRegistry()
+	var produced []string
+	producer := func(name string) inject.Producer {
+		return inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+			produced = append(produced, name)
+			return name, nil
+		})
+	}
+	if !assert.NoError(t, registry.BindSingletonWithName("config", producer("config"))) {
+		return
+	}
+	if !assert.NoError(t, registry.BindSingleton(reflect.TypeOf(&SimpleTestInterfaceImpl{}), inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+		produced = append(produced, "service")
+		return &SimpleTestInterfaceImpl{}, nil
+	}))) {
+		return
+	}
+	if !assert.NoError(t, registry.BindWithName("transient", producer("transient"))) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Warm()) {
+		return
+	}
+	assert.ElementsMatch(t, []string{"config", "service"}, produced)
+
+	_, err := registry.GetByName("config", reflect.TypeOf(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, produced, 2)
+}
+
+func TestServiceLocator_WarmJoinsErrors(t *testing.T) {
+	errConfig := errors.New("config missing")
+	errCache := errors.New("cache unreachable")
+	registry := inject.NewRegistry()
+	failing := func(err error) inject.Producer {
+		return inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+			return nil, err
+		})
+	}
+	if !assert.NoError(t, registry.BindSingletonWithName("config", failing(errConfig))) {
+		return
+	}
+	if !assert.NoError(t, registry.BindSingletonWithName("cache", failing(errCache))) {
+		return
+	}
+
+	err := registry.Warm()
+	assert.ErrorIs(t, err, errConfig)
+	assert.ErrorIs(t, err, errCache)
+}
+
+func TestServiceLocator_BindEager(t *testing.T) {
+	registry := inject.NewRegistry()
+	var produced []string
+	producer := func(name string) inject.Producer {
+		return inject.ProducerFunc(func(source interface{}, target reflect.Type) (interface{}, error) {
+			produced = append(produced, name)
+			return name, nil
+		})
+	}
+	if !assert.NoError(t, registry.BindEager("eager", producer("eager"))) {
+		return
+	}
+	if !assert.NoError(t, registry.BindSingletonWithName("lazy", producer("lazy"))) {
+		return
+	}
+
+	if !assert.NoError(t, registry.Populate()) {
+		return
+	}
+	assert.Equal(t, []string{"eager"}, produced)
+}